@@ -0,0 +1,27 @@
+// Command fakeupstream serves the canned PokeAPI and OpenWeather responses of pkg/fakeupstream, e.g.
+//
+//	go run ./cmd/fakeupstream -addr :9090
+//	API_POKEMON_BASE_URL=http://localhost:9090/api/v2 API_OPENWEATHER_BASE_URL=http://localhost:9090/data/2.5 go run .
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/zainokta/item-sync/pkg/fakeupstream"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	apiKey := flag.String("openweather-api-key", "", "OpenWeather key to require, any key is accepted when empty")
+	flag.Parse()
+
+	catalog := fakeupstream.DefaultCatalog()
+	catalog.OpenWeatherAPIKey = *apiKey
+
+	log.Printf("fake upstream listening on %s (pokemon %s, openweather %s)", *addr, fakeupstream.PokemonPath, fakeupstream.OpenWeatherPath)
+	if err := http.ListenAndServe(*addr, fakeupstream.NewHandler(catalog)); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -24,6 +25,7 @@ type Config struct {
 	Worker    WorkerConfig    `envPrefix:"WORKER_"`
 	Retry     RetryConfig     `envPrefix:"RETRY_"`
 	Migration MigrationConfig `envPrefix:"MIGRATION_"`
+	Tracing   TracingConfig   `envPrefix:"TRACING_"`
 }
 
 type ServerConfig struct {
@@ -34,6 +36,10 @@ type ServerConfig struct {
 	WriteTimeout    time.Duration `env:"WRITE_TIMEOUT" envDefault:"30s"`
 	IdleTimeout     time.Duration `env:"IDLE_TIMEOUT" envDefault:"120s"`
 	MaxRequestSize  int64         `env:"MAX_REQUEST_SIZE"`
+	// Longest a single dependency check of /health/ready may take before it is reported as down
+	HealthCheckTimeout time.Duration `env:"HEALTH_CHECK_TIMEOUT" envDefault:"2s"`
+	// Bearer token required by the /admin endpoints, they are not served when it is empty
+	AdminToken string `env:"ADMIN_TOKEN"`
 }
 
 type CORSConfig struct {
@@ -54,6 +60,7 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `env:"MAX_OPEN_CONNS" envDefault:"25"`
 	MaxIdleConns    int           `env:"MAX_IDLE_CONNS" envDefault:"25"`
 	ConnMaxLifetime time.Duration `env:"CONN_MAX_LIFETIME" envDefault:"5m"`
+	UpsertBatchSize int           `env:"UPSERT_BATCH_SIZE" envDefault:"100"` // rows per multi-row upsert statement
 }
 
 type RedisConfig struct {
@@ -64,19 +71,39 @@ type RedisConfig struct {
 }
 
 type APIConfig struct {
-	Timeout    time.Duration `env:"TIMEOUT" envDefault:"30s"`
-	MaxRetries int           `env:"MAX_RETRIES" envDefault:"3"`
-	RetryDelay time.Duration `env:"RETRY_DELAY" envDefault:"1s"`
-	RateLimit  int           `env:"RATE_LIMIT" envDefault:"100"` // requests per minute
+	Timeout        time.Duration `env:"TIMEOUT" envDefault:"30s"`
+	MaxRetries     int           `env:"MAX_RETRIES" envDefault:"3"`
+	RetryDelay     time.Duration `env:"RETRY_DELAY" envDefault:"1s"`
+	RateLimit      int           `env:"RATE_LIMIT" envDefault:"100"`      // requests per minute per upstream, 0 disables the limiter
+	RateLimitBurst int           `env:"RATE_LIMIT_BURST" envDefault:"10"` // requests that may be sent back to back
 
 	// HTTP Transport Configuration
 	MaxIdleConns        int           `env:"MAX_IDLE_CONNS" envDefault:"10"`
 	IdleConnTimeout     time.Duration `env:"IDLE_CONN_TIMEOUT" envDefault:"30s"`
 	DisableCompression  bool          `env:"DISABLE_COMPRESSION" envDefault:"false"`
 	MaxIdleConnsPerHost int           `env:"MAX_IDLE_CONNS_PER_HOST" envDefault:"10"`
+	ProxyURL            string        `env:"PROXY_URL"` // empty sends requests directly
+
+	// Upstream base URL, meant to be set per source such as API_POKEMON_BASE_URL to point a source at a stand-in
+	// like pkg/fakeupstream. Empty uses the public API.
+	BaseURL string `env:"BASE_URL"`
+
+	// Send If-None-Match / If-Modified-Since for listing pages fetched before, a 304 page is not stored again.
+	// Validators and the last body of each page URL are kept in Redis for ConditionalCacheTTL.
+	ConditionalRequests bool          `env:"CONDITIONAL_REQUESTS" envDefault:"true"`
+	ConditionalCacheTTL time.Duration `env:"CONDITIONAL_CACHE_TTL" envDefault:"168h"`
 
 	// OpenWeather API Key (when using openweather API type)
 	OpenWeatherAPIKey string `env:"OPENWEATHER_API_KEY"`
+
+	// Optional YAML/JSON file declaring additional REST providers
+	ProvidersFile string `env:"PROVIDERS_FILE"`
+
+	// Follow each listed pokemon to /pokemon/{id} and store its types, stats, abilities and sprites
+	PokemonEnrichDetails     bool          `env:"POKEMON_ENRICH_DETAILS" envDefault:"false"`
+	PokemonEnrichConcurrency int           `env:"POKEMON_ENRICH_CONCURRENCY" envDefault:"4"`
+	PokemonEnrichRefresh     time.Duration `env:"POKEMON_ENRICH_REFRESH" envDefault:"24h"`     // details younger than this are not refetched
+	PokemonEnrichCacheSize   int           `env:"POKEMON_ENRICH_CACHE_SIZE" envDefault:"2000"` // enrichments kept per client, least recently used first out
 }
 
 type CacheConfig struct {
@@ -90,6 +117,23 @@ type WorkerConfig struct {
 	SyncInterval time.Duration `env:"SYNC_INTERVAL" envDefault:"15m"`
 	JobTimeout   time.Duration `env:"JOB_TIMEOUT" envDefault:"10m"`
 	MaxWorkers   int           `env:"MAX_WORKERS" envDefault:"5"`
+
+	// Per-source overrides keyed by API source, e.g. "openweather=*/5 * * * *;pokemon=@daily"
+	Schedules    map[string]string        `env:"SCHEDULES" envSeparator:";" envKeyValSeparator:"="`
+	Jitter       map[string]time.Duration `env:"JITTER" envKeyValSeparator:"="`
+	RunOnStartup []string                 `env:"RUN_ON_STARTUP"`
+
+	// What to do when a source is due while it is still syncing: skip, queue or cancel-previous
+	DefaultOverlapPolicy string            `env:"DEFAULT_OVERLAP_POLICY" envDefault:"skip"`
+	OverlapPolicy        map[string]string `env:"OVERLAP_POLICY" envKeyValSeparator:"="`
+
+	// What a complete sync does with stored items the source no longer returns: hard-delete, soft-delete or flag-only
+	DefaultDeletionPolicy string            `env:"DEFAULT_DELETION_POLICY" envDefault:"flag-only"`
+	DeletionPolicy        map[string]string `env:"DELETION_POLICY" envKeyValSeparator:"="`
+
+	// Redis lease so that only one replica runs each schedule slot, renewed every LockTTL/3
+	LockEnabled bool          `env:"LOCK_ENABLED" envDefault:"true"`
+	LockTTL     time.Duration `env:"LOCK_TTL" envDefault:"30s"`
 }
 
 type RetryConfig struct {
@@ -99,6 +143,17 @@ type RetryConfig struct {
 	BackoffFactor    float64       `env:"BACKOFF_FACTOR" envDefault:"2.0"`
 	CircuitThreshold int           `env:"CIRCUIT_THRESHOLD" envDefault:"5"`
 	CircuitTimeout   time.Duration `env:"CIRCUIT_TIMEOUT" envDefault:"60s"`
+
+	// Calls let through while half-open, the breaker closes once all of them succeeded
+	CircuitHalfOpenProbes int `env:"CIRCUIT_HALF_OPEN_PROBES" envDefault:"1"`
+
+	// Also open when at least this share of the calls in the window failed (0 disables, e.g. 0.5), once the window
+	// holds CircuitMinCalls calls. The window is the last CircuitWindowSize calls, or the calls of the last
+	// CircuitWindow when no size is set.
+	CircuitFailureRate float64       `env:"CIRCUIT_FAILURE_RATE" envDefault:"0"`
+	CircuitWindow      time.Duration `env:"CIRCUIT_WINDOW" envDefault:"60s"`
+	CircuitWindowSize  int           `env:"CIRCUIT_WINDOW_SIZE" envDefault:"0"`
+	CircuitMinCalls    int           `env:"CIRCUIT_MIN_CALLS" envDefault:"10"`
 }
 
 type MigrationConfig struct {
@@ -107,6 +162,15 @@ type MigrationConfig struct {
 	FailOnError    bool   `env:"FAIL_ON_ERROR" envDefault:"true"`
 }
 
+// TracingConfig selects where OpenTelemetry spans are exported: "otlp" (OTLP over HTTP), "stdout" or "disabled"
+type TracingConfig struct {
+	Exporter    string  `env:"EXPORTER" envDefault:"disabled"`
+	Endpoint    string  `env:"ENDPOINT"` // host:port of the OTLP receiver, empty uses OTEL_EXPORTER_OTLP_* or localhost:4318
+	Insecure    bool    `env:"INSECURE" envDefault:"false"`
+	ServiceName string  `env:"SERVICE_NAME" envDefault:"item-sync"`
+	SampleRatio float64 `env:"SAMPLE_RATIO" envDefault:"1.0"`
+}
+
 func LoadConfig() (*Config, error) {
 	environment := os.Getenv("ENV")
 	if environment == "" {
@@ -121,6 +185,64 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// ForSource returns the API settings of one source, API_<SOURCE>_* variables such as API_POKEMON_TIMEOUT
+// override the shared API_* ones
+func (c APIConfig) ForSource(source string) (APIConfig, error) {
+	if err := parseSourceOverrides(&c, "API_", source); err != nil {
+		return APIConfig{}, err
+	}
+	if c.ProxyURL != "" {
+		if _, err := url.Parse(c.ProxyURL); err != nil {
+			return APIConfig{}, fmt.Errorf("invalid proxy URL for %s: %w", source, err)
+		}
+	}
+	return c, nil
+}
+
+// ForSource returns the retry and circuit breaker settings of one source, RETRY_<SOURCE>_* variables such as
+// RETRY_OPENWEATHER_MAX_RETRIES override the shared RETRY_* ones
+func (c RetryConfig) ForSource(source string) (RetryConfig, error) {
+	if err := parseSourceOverrides(&c, "RETRY_", source); err != nil {
+		return RetryConfig{}, err
+	}
+	return c, nil
+}
+
+// ForBreaker returns the settings of one circuit breaker, RETRY_<BREAKER>_* variables such as
+// RETRY_POKEMON_API_CIRCUIT_THRESHOLD override the settings of the source using it. Breaker names are resolved like
+// source names, the "-api" suffix of breaker names keeps their variables apart from the RETRY_<SOURCE>_* ones so a
+// breaker can be tuned without changing the retries of its source.
+func (c RetryConfig) ForBreaker(name string) (RetryConfig, error) {
+	return c.ForSource(name)
+}
+
+// parseSourceOverrides sets the fields that have a <prefix><SOURCE>_ variable and leaves the others untouched
+func parseSourceOverrides(target interface{}, prefix, source string) error {
+	err := env.ParseWithOptions(target, env.Options{
+		Prefix: prefix + SourceEnvKey(source) + "_",
+		// Unset overrides must keep the shared value instead of falling back to envDefault
+		DefaultValueTagName: "sourceDefault",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to parse %s overrides for %s: %w", strings.TrimSuffix(prefix, "_"), source, err)
+	}
+	return nil
+}
+
+// SourceEnvKey turns a source name into the form used in environment variable names, e.g. "my-api" into "MY_API"
+func SourceEnvKey(source string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, source)
+}
+
 func (c *Config) CovertLogLevel(logLevel string) logger.LogLevel {
 	switch logLevel {
 	case "info":
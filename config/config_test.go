@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,3 +42,71 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIConfig_ForSource(t *testing.T) {
+	t.Setenv("API_TIMEOUT", "30s")
+	t.Setenv("API_RATE_LIMIT", "100")
+	t.Setenv("API_POKEMON_TIMEOUT", "5s")
+	t.Setenv("API_POKEMON_PROXY_URL", "http://proxy.internal:3128")
+	t.Setenv("API_MY_API_RATE_LIMIT", "10")
+
+	cfg, err := LoadConfig()
+	assert.NoError(t, err)
+
+	pokemon, err := cfg.API.ForSource("pokemon")
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, pokemon.Timeout, "Source override should win")
+	assert.Equal(t, "http://proxy.internal:3128", pokemon.ProxyURL)
+	assert.Equal(t, 100, pokemon.RateLimit, "Settings without override should keep the shared value")
+	assert.Equal(t, 10, pokemon.MaxIdleConns, "Settings without override should not fall back to their default")
+
+	myAPI, err := cfg.API.ForSource("my-api")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, myAPI.RateLimit)
+	assert.Equal(t, 30*time.Second, myAPI.Timeout)
+
+	assert.Equal(t, 30*time.Second, cfg.API.Timeout, "Shared settings should not change")
+}
+
+func TestRetryConfig_ForSource(t *testing.T) {
+	t.Setenv("RETRY_MAX_RETRIES", "2")
+	t.Setenv("RETRY_OPENWEATHER_MAX_RETRIES", "7")
+	t.Setenv("RETRY_OPENWEATHER_CIRCUIT_TIMEOUT", "2m")
+
+	cfg, err := LoadConfig()
+	assert.NoError(t, err)
+
+	openWeather, err := cfg.Retry.ForSource("openweather")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, openWeather.MaxRetries)
+	assert.Equal(t, 2*time.Minute, openWeather.CircuitTimeout)
+	assert.Equal(t, 5, openWeather.CircuitThreshold)
+
+	pokemon, err := cfg.Retry.ForSource("pokemon")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pokemon.MaxRetries)
+
+	t.Setenv("RETRY_POKEMON_MAX_RETRIES", "many")
+	_, err = cfg.Retry.ForSource("pokemon")
+	assert.Error(t, err)
+}
+
+func TestRetryConfig_ForBreaker(t *testing.T) {
+	t.Setenv("RETRY_POKEMON_CIRCUIT_THRESHOLD", "8")
+	t.Setenv("RETRY_POKEMON_API_CIRCUIT_HALF_OPEN_PROBES", "3")
+	t.Setenv("RETRY_POKEMON_API_CIRCUIT_FAILURE_RATE", "0.5")
+
+	cfg, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cfg.Retry.CircuitHalfOpenProbes)
+	assert.Zero(t, cfg.Retry.CircuitFailureRate, "The failure rate should be disabled by default")
+
+	pokemon, err := cfg.Retry.ForSource("pokemon")
+	assert.NoError(t, err)
+
+	breaker, err := pokemon.ForBreaker("pokemon-api")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, breaker.CircuitHalfOpenProbes)
+	assert.Equal(t, 0.5, breaker.CircuitFailureRate)
+	assert.Equal(t, 8, breaker.CircuitThreshold, "Breaker settings without override should keep the source value")
+}
@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	stderrors "errors"
+	"fmt"
+)
 
 type ErrorCategory int
 
@@ -10,6 +13,8 @@ const (
 	CategoryCache
 	CategoryExternalAPI
 	CategoryNotFound
+	CategoryConflict
+	CategoryUnauthorized
 )
 
 type DomainError struct {
@@ -49,6 +54,15 @@ func ItemNotFound() *DomainError {
 	}
 }
 
+func ItemVersionNotFound(versionID int64) *DomainError {
+	return &DomainError{
+		Code:     "ITEM_VERSION_NOT_FOUND",
+		Message:  "item version not found",
+		Category: CategoryNotFound,
+		Details:  map[string]interface{}{"version_id": versionID},
+	}
+}
+
 func InvalidItemData(message string) *DomainError {
 	return &DomainError{
 		Code:     "INVALID_ITEM_DATA",
@@ -57,6 +71,22 @@ func InvalidItemData(message string) *DomainError {
 	}
 }
 
+func InvalidQuery(message string) *DomainError {
+	return &DomainError{
+		Code:     "INVALID_QUERY",
+		Message:  message,
+		Category: CategoryValidation,
+	}
+}
+
+func Unauthorized() *DomainError {
+	return &DomainError{
+		Code:     "UNAUTHORIZED",
+		Message:  "a valid admin token is required",
+		Category: CategoryUnauthorized,
+	}
+}
+
 func SyncFailed(cause error) *DomainError {
 	return &DomainError{
 		Code:     "SYNC_FAILED",
@@ -75,13 +105,26 @@ func CacheFailed(cause error) *DomainError {
 	}
 }
 
+// upstreamStatusError is implemented by errors carrying the HTTP status of a failed upstream call, such as api.HTTPError
+type upstreamStatusError interface {
+	error
+	HTTPStatusCode() int
+}
+
+// ExternalAPIFailed records the upstream HTTP status, when the cause has one, as the "upstream_status" detail
 func ExternalAPIFailed(cause error) *DomainError {
-	return &DomainError{
+	err := &DomainError{
 		Code:     "EXTERNAL_API_FAILED",
 		Message:  "external API failed",
 		Category: CategoryExternalAPI,
 		Cause:    cause,
 	}
+
+	var statusErr upstreamStatusError
+	if stderrors.As(cause, &statusErr) {
+		err.WithDetail("upstream_status", statusErr.HTTPStatusCode())
+	}
+	return err
 }
 
 func DatabaseError(cause error) *DomainError {
@@ -100,3 +143,47 @@ func ItemAlreadyExists() *DomainError {
 		Category: CategoryValidation,
 	}
 }
+
+func SyncJobNotFound() *DomainError {
+	return &DomainError{
+		Code:     "SYNC_JOB_NOT_FOUND",
+		Message:  "sync job not found",
+		Category: CategoryNotFound,
+	}
+}
+
+func SyncJobNotRunning(status string) *DomainError {
+	return &DomainError{
+		Code:     "SYNC_JOB_NOT_RUNNING",
+		Message:  "sync job is not running",
+		Category: CategoryConflict,
+		Details:  map[string]interface{}{"status": status},
+	}
+}
+
+func SyncJobAlreadyRunning(apiSource string, runningJobID int64) *DomainError {
+	return &DomainError{
+		Code:     "SYNC_JOB_ALREADY_RUNNING",
+		Message:  "a sync job is already running for this source",
+		Category: CategoryConflict,
+		Details:  map[string]interface{}{"api_source": apiSource, "running_job_id": runningJobID},
+	}
+}
+
+func BreakerNotFound(name string) *DomainError {
+	return &DomainError{
+		Code:     "BREAKER_NOT_FOUND",
+		Message:  "circuit breaker not found",
+		Category: CategoryNotFound,
+		Details:  map[string]interface{}{"name": name},
+	}
+}
+
+func InvalidBreakerAction(action string) *DomainError {
+	return &DomainError{
+		Code:     "INVALID_BREAKER_ACTION",
+		Message:  "unknown circuit breaker action",
+		Category: CategoryValidation,
+		Details:  map[string]interface{}{"action": action, "allowed": []string{"force-open", "force-close", "reset"}},
+	}
+}
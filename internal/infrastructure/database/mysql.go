@@ -1,10 +1,15 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 
+	"github.com/XSAM/otelsql"
 	"github.com/zainokta/item-sync/config"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
 
 	_ "github.com/go-sql-driver/mysql"
 )
@@ -18,7 +23,18 @@ func NewMysqlDatabase(cfg config.DatabaseConfig) (*sql.DB, error) {
 		cfg.Database,
 	)
 
-	db, err := sql.Open("mysql", dsn)
+	// Every query gets a span under the span of its context, queries made outside a trace are not traced
+	db, err := otelsql.Open("mysql", dsn,
+		otelsql.WithAttributes(semconv.DBSystemNameMySQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitConnPrepare:      true,
+			OmitRows:             true,
+			OmitConnectorConnect: true,
+			SpanFilter: func(ctx context.Context, method otelsql.Method, query string, args []driver.NamedValue) bool {
+				return trace.SpanContextFromContext(ctx).IsValid()
+			},
+		}))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/handler"
+)
+
+// AdminAuthMiddleware rejects requests that do not carry token as a bearer token in the Authorization header
+func AdminAuthMiddleware(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			given, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return handler.ErrorResponse(c, pkgErrors.Unauthorized())
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/internal/item/handler/dto"
+)
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+	}{
+		{name: "valid token", authorization: "Bearer secret", status: http.StatusOK},
+		{name: "wrong token", authorization: "Bearer other", status: http.StatusUnauthorized},
+		{name: "missing header", status: http.StatusUnauthorized},
+		{name: "not a bearer token", authorization: "Basic secret", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			admin := e.Group("/admin", AdminAuthMiddleware("secret"))
+			admin.GET("/breakers", func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/breakers", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.status == http.StatusUnauthorized {
+				var body dto.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, "UNAUTHORIZED", body.Code)
+				assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+			}
+		})
+	}
+}
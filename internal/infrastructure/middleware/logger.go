@@ -5,6 +5,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func LogMiddleware(logger logger.Logger) echo.MiddlewareFunc {
@@ -34,6 +35,10 @@ func LogMiddleware(logger logger.Logger) echo.MiddlewareFunc {
 				"latency":    duration,
 			}
 
+			if spanContext := trace.SpanContextFromContext(req.Context()); spanContext.IsValid() {
+				fields["trace_id"] = spanContext.TraceID().String()
+			}
+
 			// Add error if present
 			if err != nil {
 				fields["error"] = err.Error()
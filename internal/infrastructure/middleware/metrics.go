@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zainokta/item-sync/pkg/metrics"
+)
+
+// MetricsMiddleware counts requests and their latency by route template, so /items/1 and /items/2 share a series
+func MetricsMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+
+			metrics.ObserveHTTPRequest(c.Request().Method, route, responseStatus(c, err), time.Since(start))
+
+			return err
+		}
+	}
+}
+
+// responseStatus returns the status sent, or the one the error handler will send for an error not yet written
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
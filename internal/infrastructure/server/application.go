@@ -10,21 +10,25 @@ import (
 	"github.com/zainokta/item-sync/internal/infrastructure/database"
 	"github.com/zainokta/item-sync/internal/infrastructure/worker"
 	"github.com/zainokta/item-sync/internal/item/jobs"
+	"github.com/zainokta/item-sync/internal/item/provider"
 	"github.com/zainokta/item-sync/internal/item/repository"
 	"github.com/zainokta/item-sync/pkg/api"
 	loggerPkg "github.com/zainokta/item-sync/pkg/logger"
 	"github.com/zainokta/item-sync/pkg/migration"
+	"github.com/zainokta/item-sync/pkg/tracing"
 )
 
 type Application struct {
-	config    *config.Config
-	logger    loggerPkg.Logger
-	database  *sql.DB
-	redis     *redis.Client
-	server    Server
-	scheduler *worker.Scheduler
-	ctx       context.Context
-	cancel    context.CancelFunc
+	config          *config.Config
+	logger          loggerPkg.Logger
+	database        *sql.DB
+	redis           *redis.Client
+	migrator        *migration.Migrator
+	server          Server
+	scheduler       *worker.Scheduler
+	shutdownTracing func(context.Context) error
+	ctx             context.Context
+	cancel          context.CancelFunc
 }
 
 func NewApplication() (*Application, error) {
@@ -34,39 +38,45 @@ func NewApplication() (*Application, error) {
 	}
 
 	logger := loggerPkg.NewLogger(loggerPkg.LogLevel(cfg.LogLevel), cfg.Environment)
+
+	// Installed before anything that creates spans
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.Environment)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Tracing configured", "exporter", cfg.Tracing.Exporter)
+
 	db, err := database.NewMysqlDatabase(cfg.Database)
 	if err != nil {
 		return nil, err
 	}
 
+	// The migrator stays open so that readiness can check the schema version
+	logger.Info("Setting up database migrations", "path", cfg.Migration.MigrationsPath, "enabled", cfg.Migration.Enabled)
+	migrator, err := migration.NewMigrator(migration.Config{
+		DatabaseURL:    buildDatabaseURL(cfg.Database),
+		MigrationsPath: cfg.Migration.MigrationsPath,
+		Logger:         logger,
+	})
+	if err != nil {
+		if cfg.Migration.Enabled && cfg.Migration.FailOnError {
+			return nil, fmt.Errorf("failed to create migrator: %w", err)
+		}
+		logger.Warn("Failed to create migrator, continuing without migration version checks...", "error", err)
+	}
+
 	// Run database migrations automatically if enabled
-	if cfg.Migration.Enabled {
-		logger.Info("Running database migrations...", "path", cfg.Migration.MigrationsPath)
-
-		databaseURL := buildDatabaseURL(cfg.Database)
-		migrator, err := migration.NewMigrator(migration.Config{
-			DatabaseURL:    databaseURL,
-			MigrationsPath: cfg.Migration.MigrationsPath,
-			Logger:         logger,
-		})
-		if err != nil {
+	if migrator != nil && cfg.Migration.Enabled {
+		if err := migrator.Up(); err != nil {
 			if cfg.Migration.FailOnError {
-				return nil, fmt.Errorf("failed to create migrator: %w", err)
+				migrator.Close()
+				return nil, fmt.Errorf("migration failed: %w", err)
 			}
-			logger.Warn("Failed to create migrator, continuing...", "error", err)
+			logger.Warn("Migration failed, continuing...", "error", err)
 		} else {
-			defer migrator.Close()
-
-			if err := migrator.Up(); err != nil {
-				if cfg.Migration.FailOnError {
-					return nil, fmt.Errorf("migration failed: %w", err)
-				}
-				logger.Warn("Migration failed, continuing...", "error", err)
-			} else {
-				logger.Info("Database migrations completed successfully")
-			}
+			logger.Info("Database migrations completed successfully")
 		}
-	} else {
+	} else if !cfg.Migration.Enabled {
 		logger.Info("Database migrations disabled")
 	}
 
@@ -82,50 +92,87 @@ func NewApplication() (*Application, error) {
 	}
 
 	// Create repository container
-	repoContainer := repository.NewRepositoryContainer(db, redisClient, cfg.Cache.DefaultTTL, logger)
+	repoContainer := repository.NewRepositoryContainer(db, redisClient, cfg.Cache.DefaultTTL, cfg.Database.UpsertBatchSize, logger)
+
+	// API clients built from here on keep the validators of listing pages for conditional requests
+	api.UseResponseCache(repoContainer.GetResponseCache())
 
-	RegisterRoutes(server.GetEcho(), cfg, logger, repoContainer)
+	// Tracks running sync jobs so they can be cancelled through the API
+	jobRegistry := jobs.NewJobRegistry()
+
+	// Every sync source is looked up by name from the provider registry
+	providers := provider.NewDefaultRegistry()
+	if cfg.API.ProvidersFile != "" {
+		names, err := providers.RegisterRESTProviders(cfg.API.ProvidersFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register REST providers: %w", err)
+		}
+		logger.Info("Registered REST providers", "file", cfg.API.ProvidersFile, "providers", names)
+	}
+	// Per-source overrides are resolved once, a typo in one of them stops the startup
+	if err := providers.Build(cfg.API, cfg.Retry, logger); err != nil {
+		return nil, fmt.Errorf("failed to build API clients: %w", err)
+	}
+	// Breakers forced open before their first call are logged with the application logger
+	api.Breakers().WithLogger(logger)
 
 	// Create worker scheduler
 	ctx, cancel := context.WithCancel(context.Background())
-	scheduler := worker.NewScheduler(cfg.Worker, logger)
+	var locker worker.Locker
+	if cfg.Worker.LockEnabled {
+		locker = worker.NewRedisLocker(redisClient)
+	}
+	scheduler := worker.NewScheduler(cfg.Worker, logger, locker)
+
+	readiness := newReadinessChecker(cfg, db, redisClient, migrator, scheduler)
+	RegisterRoutes(server.GetEcho(), cfg, logger, repoContainer, jobRegistry, providers, readiness)
 
 	// Create and register sync jobs if worker is enabled
 	if cfg.Worker.Enabled {
-		availableAPIs := []string{"pokemon", "openweather"}
+		for _, syncProvider := range providers.All() {
+			name := syncProvider.Name
 
-		// Create API client
-		for _, availableAPI := range availableAPIs {
-			apiClient, err := api.NewAPIClient(availableAPI, cfg.API, cfg.Retry, logger)
+			syncStrategy, err := providers.SyncStrategy(name)
 			if err != nil {
-				logger.Warn("Failed to create API client for worker", "error", err)
-			} else {
-				// Register sync job
-				syncJob := jobs.NewSyncJob(
-					"background-sync",
-					repoContainer.GetItemRepository(),
-					repoContainer.GetJobRepository(),
-					apiClient,
-					availableAPI,
-					logger,
-					*cfg,
-					nil,
-				)
-				scheduler.RegisterJob(syncJob)
+				cancel()
+				return nil, err
+			}
+
+			options, err := worker.JobOptionsFor(cfg.Worker, name)
+			if err != nil {
+				cancel()
+				return nil, err
 			}
-		}
 
+			// Register sync job
+			syncJob := jobs.NewSyncJob(
+				fmt.Sprintf("background-sync-%s", name),
+				repoContainer.GetItemRepository(),
+				repoContainer.GetJobRepository(),
+				repoContainer.GetItemCache(),
+				jobRegistry,
+				syncStrategy,
+				name,
+				syncProvider.Operation,
+				logger,
+				*cfg,
+				syncProvider.JobParams(nil),
+			)
+			scheduler.RegisterJob(syncJob, options)
+		}
 	}
 
 	return &Application{
-		config:    cfg,
-		logger:    logger,
-		database:  db,
-		redis:     redisClient,
-		server:    server,
-		scheduler: scheduler,
-		ctx:       ctx,
-		cancel:    cancel,
+		config:          cfg,
+		logger:          logger,
+		database:        db,
+		redis:           redisClient,
+		migrator:        migrator,
+		server:          server,
+		scheduler:       scheduler,
+		shutdownTracing: shutdownTracing,
+		ctx:             ctx,
+		cancel:          cancel,
 	}, nil
 }
 
@@ -164,6 +211,13 @@ func (a *Application) Shutdown() {
 		a.scheduler.Stop()
 	}
 
+	// Close the connection of the migrator
+	if a.migrator != nil {
+		if err := a.migrator.Close(); err != nil {
+			a.logger.Error("Failed to close migrator", "error", err)
+		}
+	}
+
 	// Close database connection
 	if a.database != nil {
 		if err := a.database.Close(); err != nil {
@@ -182,6 +236,15 @@ func (a *Application) Shutdown() {
 	if err := a.server.Stop(); err != nil {
 		a.logger.Error("Failed to stop server", "error", err)
 	}
+
+	// Flush spans last, the steps above may still end some
+	if a.shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), a.config.Server.GracefulTimeout)
+		defer cancel()
+		if err := a.shutdownTracing(ctx); err != nil {
+			a.logger.Error("Failed to flush traces", "error", err)
+		}
+	}
 }
 
 func buildDatabaseURL(dbConfig config.DatabaseConfig) string {
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/infrastructure/worker"
+	"github.com/zainokta/item-sync/pkg/health"
+	"github.com/zainokta/item-sync/pkg/migration"
+)
+
+// newReadinessChecker builds the checks of /health/ready. Redis only backs the cache and the job leases, both of
+// which fall back to the database, so it is optional. migrator is nil when migrations could not be set up.
+func newReadinessChecker(cfg *config.Config, db *sql.DB, redisClient *redis.Client, migrator *migration.Migrator, scheduler *worker.Scheduler) *health.Checker {
+	checks := []health.Check{
+		{Name: "database", Required: true, Run: func(ctx context.Context) (map[string]interface{}, error) {
+			return nil, db.PingContext(ctx)
+		}},
+		{Name: "redis", Required: false, Run: func(ctx context.Context) (map[string]interface{}, error) {
+			return nil, redisClient.Ping(ctx).Err()
+		}},
+	}
+
+	if migrator != nil {
+		checks = append(checks, health.Check{Name: "migrations", Required: true, Run: func(ctx context.Context) (map[string]interface{}, error) {
+			version, dirty, err := migrator.Version()
+			if err != nil {
+				return nil, err
+			}
+			details := map[string]interface{}{"version": version, "dirty": dirty}
+			if dirty {
+				return details, fmt.Errorf("migration %d failed halfway and needs to be fixed manually", version)
+			}
+			if version == 0 {
+				return details, errors.New("no migrations applied")
+			}
+			return details, nil
+		}})
+	}
+
+	if cfg.Worker.Enabled {
+		checks = append(checks, health.Check{Name: "scheduler", Required: true, Run: func(ctx context.Context) (map[string]interface{}, error) {
+			var details map[string]interface{}
+			if last := scheduler.LastHeartbeat(); !last.IsZero() {
+				details = map[string]interface{}{"last_heartbeat": last}
+			}
+			return details, scheduler.CheckAlive(3 * worker.HeartbeatInterval)
+		}})
+	}
+
+	return health.NewChecker(cfg.Server.HealthCheckTimeout, checks...)
+}
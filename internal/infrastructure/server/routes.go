@@ -1,26 +1,45 @@
 package server
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"github.com/zainokta/item-sync/config"
 	_ "github.com/zainokta/item-sync/docs"
+	"github.com/zainokta/item-sync/internal/infrastructure/middleware"
 	"github.com/zainokta/item-sync/internal/item/handler"
+	"github.com/zainokta/item-sync/internal/item/jobs"
+	"github.com/zainokta/item-sync/internal/item/provider"
 	"github.com/zainokta/item-sync/internal/item/repository"
 	"github.com/zainokta/item-sync/internal/item/usecase"
+	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/pkg/health"
 	loggerPkg "github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/metrics"
 )
 
-func RegisterRoutes(e *echo.Echo, cfg *config.Config, logger loggerPkg.Logger, repoContainer *repository.RepositoryContainer) {
+func RegisterRoutes(e *echo.Echo, cfg *config.Config, logger loggerPkg.Logger, repoContainer *repository.RepositoryContainer, jobRegistry *jobs.JobRegistry, providers *provider.Registry, readiness *health.Checker) {
 	// Create use cases with configured API client
-	syncUseCase := usecase.NewSyncItemsUseCase(cfg, repoContainer.GetItemRepository(), repoContainer.GetJobRepository(), logger)
+	syncUseCase := usecase.NewSyncItemsUseCase(cfg, providers, repoContainer.GetItemRepository(), repoContainer.GetJobRepository(), repoContainer.GetItemCache(), jobRegistry, logger)
 	listUseCase := usecase.NewListItemsUseCase(repoContainer.GetItemRepository(), repoContainer.GetItemCache(), logger)
-	detailUseCase := usecase.NewFetchItemUseCase(cfg, repoContainer.GetItemRepository(), repoContainer.GetItemCache(), logger)
+	detailUseCase := usecase.NewFetchItemUseCase(cfg, providers, repoContainer.GetItemRepository(), repoContainer.GetItemCache(), logger)
+	listSyncJobsUseCase := usecase.NewListSyncJobsUseCase(repoContainer.GetJobRepository(), logger)
+	fetchSyncJobUseCase := usecase.NewFetchSyncJobUseCase(repoContainer.GetJobRepository(), logger)
+	cancelSyncJobUseCase := usecase.NewCancelSyncJobUseCase(repoContainer.GetJobRepository(), jobRegistry, logger)
+	itemHistoryUseCase := usecase.NewItemHistoryUseCase(repoContainer.GetItemRepository(), repoContainer.GetItemVersionRepository(), logger)
+	itemDiffUseCase := usecase.NewItemDiffUseCase(repoContainer.GetItemVersionRepository(), logger)
+	rateLimitsUseCase := usecase.NewListRateLimitsUseCase(api.RateLimiters(), logger)
+	breakersUseCase := usecase.NewListBreakersUseCase(api.Breakers(), logger)
+	breakerActionUseCase := usecase.NewBreakerActionUseCase(api.Breakers(), logger)
 
 	// Create handlers
 	syncHandler := handler.NewSyncHandler(syncUseCase, logger)
 	listHandler := handler.NewListHandler(listUseCase, logger)
 	detailHandler := handler.NewItemDetailHandler(detailUseCase, logger)
+	syncJobHandler := handler.NewSyncJobHandler(listSyncJobsUseCase, fetchSyncJobUseCase, cancelSyncJobUseCase, logger)
+	itemHistoryHandler := handler.NewItemHistoryHandler(itemHistoryUseCase, itemDiffUseCase, logger)
+	upstreamHandler := handler.NewUpstreamHandler(rateLimitsUseCase, breakersUseCase, breakerActionUseCase, logger)
 
 	// Health check endpoint
 	// @Summary      Health check
@@ -36,9 +55,63 @@ func RegisterRoutes(e *echo.Echo, cfg *config.Config, logger loggerPkg.Logger, r
 		})
 	})
 
+	// Liveness probe
+	// @Summary      Liveness probe
+	// @Description  Answers as long as the process serves HTTP, dependencies are not checked
+	// @Tags         health
+	// @Produce      json
+	// @Success      200 {object} map[string]string "Process is alive"
+	// @Router       /health/live [get]
+	e.GET("/health/live", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{
+			"status": "alive",
+		})
+	})
+
+	// Readiness probe
+	// @Summary      Readiness probe
+	// @Description  Checks the database, Redis, the migration version and the worker scheduler, with the status and latency of each. Failing optional dependencies report the service as degraded.
+	// @Tags         health
+	// @Produce      json
+	// @Success      200 {object} health.Report "Required dependencies are up"
+	// @Failure      503 {object} health.Report "A required dependency is down"
+	// @Router       /health/ready [get]
+	e.GET("/health/ready", func(c echo.Context) error {
+		report := readiness.Run(c.Request().Context())
+		if !report.Ready() {
+			logger.Warn("Readiness check failed", "checks", report.Checks)
+			return c.JSON(http.StatusServiceUnavailable, report)
+		}
+		return c.JSON(http.StatusOK, report)
+	})
+
+	// Prometheus metrics in text format
+	// @Summary      Metrics
+	// @Description  HTTP, sync job, upstream call and cache metrics in the Prometheus text format
+	// @Tags         health
+	// @Produce      plain
+	// @Success      200 {string} string "Prometheus metrics"
+	// @Router       /metrics [get]
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+
 	e.POST("/sync", syncHandler.SyncItems)
+	e.GET("/sync/jobs", syncJobHandler.ListSyncJobs)
+	e.GET("/sync/jobs/:id", syncJobHandler.GetSyncJob)
+	e.DELETE("/sync/jobs/:id", syncJobHandler.CancelSyncJob)
 	e.GET("/items", listHandler.ListItems)
 	e.GET("/items/:id", detailHandler.GetItemDetail)
+	e.GET("/items/:id/history", itemHistoryHandler.GetItemHistory)
+	e.GET("/items/:id/diff", itemHistoryHandler.GetItemDiff)
+
+	// Admin endpoints change how this replica calls the upstreams, they are only served behind a token
+	if cfg.Server.AdminToken != "" {
+		admin := e.Group("/admin", middleware.AdminAuthMiddleware(cfg.Server.AdminToken))
+		admin.GET("/rate-limits", upstreamHandler.GetRateLimits)
+		admin.GET("/breakers", upstreamHandler.GetBreakers)
+		admin.POST("/breakers/:name/:action", upstreamHandler.ApplyBreakerAction)
+	} else {
+		logger.Warn("SERVER_ADMIN_TOKEN is not set, the /admin endpoints are disabled")
+	}
 
 	// Swagger documentation endpoints
 	// Only serve Swagger UI in development and staging environments
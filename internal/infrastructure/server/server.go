@@ -38,7 +38,9 @@ func NewEchoServer(cfg *config.Config, appLogger logger.Logger) (*EchoServer, er
 	}))
 	e.Use(echoMiddleware.CORSWithConfig(cfg.CORS.ToEchoCORSConfig()))
 	e.Use(echoMiddleware.RequestID())
+	e.Use(middleware.TracingMiddleware())
 	e.Use(middleware.LogMiddleware(appLogger))
+	e.Use(middleware.MetricsMiddleware())
 
 	e.Server.ReadTimeout = cfg.Server.ReadTimeout
 	e.Server.WriteTimeout = cfg.Server.WriteTimeout
@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// LeaseState describes the outcome of a lease acquisition
+type LeaseState int
+
+const (
+	// LeaseAcquired means this instance now owns the slot
+	LeaseAcquired LeaseState = iota
+	// LeaseHeld means another instance is running the slot
+	LeaseHeld
+	// LeaseCompleted means the slot has already been run
+	LeaseCompleted
+)
+
+// Locker hands out one lease per job schedule slot so that only one instance runs it
+type Locker interface {
+	Acquire(ctx context.Context, key string, ttl time.Duration) (Lease, LeaseState, error)
+}
+
+// Lease is owned by the instance running a slot
+type Lease interface {
+	// Renew extends the lease, it returns entity.ErrLeaseLost once the lease is no longer owned
+	Renew(ctx context.Context, ttl time.Duration) error
+	// Complete marks the slot as done and keeps that marker for the retain duration
+	Complete(ctx context.Context, retain time.Duration) error
+}
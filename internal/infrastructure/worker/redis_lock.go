@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zainokta/item-sync/internal/item/entity"
+)
+
+const (
+	lockKeyPrefix   = "item-sync:worker:lock:"
+	completedPrefix = "done:"
+)
+
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var completeScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+end
+return 0
+`)
+
+// RedisLocker implements Locker with SET NX leases that expire when the holder dies
+type RedisLocker struct {
+	client *redis.Client
+	owner  string
+}
+
+func NewRedisLocker(client *redis.Client) *RedisLocker {
+	return &RedisLocker{
+		client: client,
+		owner:  newOwnerID(),
+	}
+}
+
+func (l *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lease, LeaseState, error) {
+	key = lockKeyPrefix + key
+
+	acquired, err := l.client.SetNX(ctx, key, l.owner, ttl).Result()
+	if err != nil {
+		return nil, LeaseHeld, fmt.Errorf("failed to acquire lease %s: %w", key, err)
+	}
+	if acquired {
+		return &redisLease{client: l.client, key: key, owner: l.owner}, LeaseAcquired, nil
+	}
+
+	value, err := l.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		// Expired between SETNX and GET, the next attempt will take it
+		return nil, LeaseHeld, nil
+	}
+	if err != nil {
+		return nil, LeaseHeld, fmt.Errorf("failed to read lease %s: %w", key, err)
+	}
+
+	if strings.HasPrefix(value, completedPrefix) {
+		return nil, LeaseCompleted, nil
+	}
+	return nil, LeaseHeld, nil
+}
+
+type redisLease struct {
+	client *redis.Client
+	key    string
+	owner  string
+}
+
+func (l *redisLease) Renew(ctx context.Context, ttl time.Duration) error {
+	renewed, err := renewScript.Run(ctx, l.client, []string{l.key}, l.owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return fmt.Errorf("failed to renew lease %s: %w", l.key, err)
+	}
+	if renewed == 0 {
+		return entity.ErrLeaseLost
+	}
+	return nil
+}
+
+func (l *redisLease) Complete(ctx context.Context, retain time.Duration) error {
+	completed, err := completeScript.Run(ctx, l.client, []string{l.key}, l.owner, completedPrefix+l.owner, retain.Milliseconds()).Int()
+	if err != nil {
+		return fmt.Errorf("failed to complete lease %s: %w", l.key, err)
+	}
+	if completed == 0 {
+		return entity.ErrLeaseLost
+	}
+	return nil
+}
+
+func newOwnerID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(suffix))
+}
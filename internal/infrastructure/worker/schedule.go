@@ -0,0 +1,206 @@
+package worker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes the next activation time of a job
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// ParseSchedule accepts a standard 5-field cron expression (minute hour day-of-month month day-of-week),
+// one of the @hourly, @daily, @midnight, @weekly, @monthly, @yearly, @annually descriptors,
+// "@every <duration>" or a bare Go duration such as "5m"
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("empty schedule")
+	}
+
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	case "@yearly", "@annually":
+		spec = "0 0 1 1 *"
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		return parseInterval(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+	}
+	if strings.HasPrefix(spec, "@") {
+		return nil, fmt.Errorf("unknown schedule descriptor %q", spec)
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) == 1 {
+		return parseInterval(fields[0])
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, got %d", spec, len(fields))
+	}
+
+	return parseCron(fields)
+}
+
+// Every returns a schedule firing at a fixed interval. Runs are aligned to multiples of the interval
+// so that every instance computes the same schedule slots.
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule{interval: interval}
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+func (s intervalSchedule) Next(after time.Time) time.Time {
+	return after.Truncate(s.interval).Add(s.interval)
+}
+
+func parseInterval(value string) (Schedule, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval %q: %w", value, err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive, got %s", interval)
+	}
+	return Every(interval), nil
+}
+
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+func parseCron(fields []string) (Schedule, error) {
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		value, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = value
+	}
+
+	// Both 0 and 7 mean Sunday
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField handles "*", single values, ranges "a-b", lists "a,b" and steps "*/n" or "a-b/n"
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if base, stepValue, ok := strings.Cut(part, "/"); ok {
+			parsed, err := strconv.Atoi(stepValue)
+			if err != nil || parsed <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepValue, spec.name)
+			}
+			step = parsed
+			part = base
+		}
+
+		start, end := spec.min, spec.max
+		if part != "*" {
+			low, high, isRange := strings.Cut(part, "-")
+			var err error
+			if start, err = strconv.Atoi(low); err != nil {
+				return 0, fmt.Errorf("invalid value %q in %s field", part, spec.name)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(high); err != nil {
+					return 0, fmt.Errorf("invalid value %q in %s field", part, spec.name)
+				}
+			} else if step > 1 {
+				end = spec.max
+			}
+		}
+
+		if start < spec.min || end > spec.max || start > end {
+			return 0, fmt.Errorf("value %q out of range %d-%d in %s field", part, spec.min, spec.max, spec.name)
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+
+	// Every valid expression matches at least once within a few years (e.g. Feb 29)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches follows cron semantics: when both day fields are restricted either one may match
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, time.January, 17, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		want time.Time
+	}{
+		{name: "bare duration", spec: "5m", want: time.Date(2024, time.January, 17, 10, 10, 0, 0, time.UTC)},
+		{name: "every descriptor", spec: "@every 2h", want: time.Date(2024, time.January, 17, 12, 0, 0, 0, time.UTC)},
+		{name: "step minutes", spec: "*/5 * * * *", want: time.Date(2024, time.January, 17, 10, 10, 0, 0, time.UTC)},
+		{name: "daily descriptor", spec: "@daily", want: time.Date(2024, time.January, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "hour list", spec: "30 6,18 * * *", want: time.Date(2024, time.January, 17, 18, 30, 0, 0, time.UTC)},
+		{name: "weekday range", spec: "0 9 * * 1-5", want: time.Date(2024, time.January, 18, 9, 0, 0, 0, time.UTC)},
+		{name: "sunday as 7", spec: "0 0 * * 7", want: time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or day of week", spec: "0 0 1 * 5", want: time.Date(2024, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day", spec: "0 0 29 2 *", want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	specs := []string{"", "@sometimes", "* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "-5m", "@every soon"}
+
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			_, err := ParseSchedule(spec)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -2,10 +2,17 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/internal/item/jobs"
 	"github.com/zainokta/item-sync/pkg/logger"
 )
 
@@ -14,30 +21,143 @@ type Job interface {
 	Execute(ctx context.Context) error
 }
 
+// JobOptions controls when a registered job runs
+type JobOptions struct {
+	// Schedule defaults to the configured SyncInterval when nil
+	Schedule Schedule
+	// Jitter adds a random delay in [0, Jitter) to every run to spread load on upstream APIs
+	Jitter       time.Duration
+	RunOnStartup bool
+	// Overlap defaults to entity.OverlapSkip
+	Overlap entity.OverlapPolicy
+}
+
+// JobOptionsFor resolves the per-source schedule overrides from the worker config
+func JobOptionsFor(config config.WorkerConfig, source string) (JobOptions, error) {
+	options := JobOptions{
+		Jitter:       config.Jitter[source],
+		RunOnStartup: slices.Contains(config.RunOnStartup, source),
+	}
+
+	if spec, ok := config.Schedules[source]; ok {
+		schedule, err := ParseSchedule(spec)
+		if err != nil {
+			return JobOptions{}, fmt.Errorf("invalid schedule for %s: %w", source, err)
+		}
+		options.Schedule = schedule
+	}
+
+	overlap, err := jobs.OverlapPolicyFor(config, source)
+	if err != nil {
+		return JobOptions{}, err
+	}
+	options.Overlap = overlap
+
+	return options, nil
+}
+
+type scheduledJob struct {
+	job     Job
+	options JobOptions
+	// slot is the nominal schedule time of the next run, nextRun adds the jitter
+	slot    runSlot
+	nextRun time.Time
+
+	// Run state, guarded by Scheduler.mu
+	running     bool
+	pending     bool
+	pendingSlot runSlot
+	cancel      context.CancelCauseFunc
+}
+
+// runSlot identifies the run instances compete for through the Locker
+type runSlot struct {
+	at time.Time
+	// The startup run is leased apart from the regular run of the same slot, so that it does not replace it
+	startup bool
+}
+
+// startupSlot returns the slot of the startup run. Instances starting before the next regular slot share it,
+// whenever each of them started.
+func startupSlot(schedule Schedule, now time.Time) runSlot {
+	at := schedule.Next(now)
+	if at.IsZero() {
+		at = now
+	}
+	return runSlot{at: at, startup: true}
+}
+
+// leaseKey returns the Locker key of the slot
+func (r runSlot) leaseKey(name string) string {
+	if r.startup {
+		return fmt.Sprintf("%s:startup:%d", name, r.at.Unix())
+	}
+	return fmt.Sprintf("%s:%d", name, r.at.Unix())
+}
+
+// HeartbeatInterval is how often the scheduling loop proves it is alive, see Scheduler.CheckAlive
+const HeartbeatInterval = 10 * time.Second
+
+// ErrSchedulerNotRunning is returned by CheckAlive when the scheduling loop is not running
+var ErrSchedulerNotRunning = errors.New("scheduler loop is not running")
+
 type Scheduler struct {
 	config   config.WorkerConfig
 	logger   logger.Logger
-	jobs     map[string]Job
+	locker   Locker
+	jobs     map[string]*scheduledJob
 	stopChan chan struct{}
+	wakeChan chan struct{}
 	wg       sync.WaitGroup
 	mu       sync.RWMutex
 	running  bool
+
+	// Unix nanoseconds of the last pass of the scheduling loop, zero while the loop is not running
+	heartbeat atomic.Int64
 }
 
-func NewScheduler(config config.WorkerConfig, logger logger.Logger) *Scheduler {
+// NewScheduler creates a scheduler, locker may be nil when a single instance runs the jobs
+func NewScheduler(config config.WorkerConfig, logger logger.Logger, locker Locker) *Scheduler {
 	return &Scheduler{
 		config:   config,
 		logger:   logger,
-		jobs:     make(map[string]Job),
+		locker:   locker,
+		jobs:     make(map[string]*scheduledJob),
 		stopChan: make(chan struct{}),
+		wakeChan: make(chan struct{}, 1),
 	}
 }
 
-func (s *Scheduler) RegisterJob(job Job) {
+func (s *Scheduler) RegisterJob(job Job, options JobOptions) {
+	if options.Schedule == nil {
+		options.Schedule = Every(s.config.SyncInterval)
+	}
+	if options.Overlap == "" {
+		options.Overlap = entity.OverlapSkip
+	}
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.jobs[job.Name()] = job
-	s.logger.Info("Job registered", "name", job.Name())
+	entry := &scheduledJob{job: job, options: options}
+	if s.running {
+		s.scheduleFirst(entry, time.Now())
+	}
+	s.jobs[job.Name()] = entry
+	s.mu.Unlock()
+
+	s.wake()
+	s.logger.Info("Job registered", "name", job.Name(), "run_on_startup", options.RunOnStartup, "jitter", options.Jitter)
+}
+
+// NextRuns returns the next scheduled run time of every registered job
+func (s *Scheduler) NextRuns() map[string]time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	nextRuns := make(map[string]time.Time, len(s.jobs))
+	for name, entry := range s.jobs {
+		nextRuns[name] = entry.nextRun
+	}
+	return nextRuns
 }
 
 func (s *Scheduler) Start(ctx context.Context) error {
@@ -54,10 +174,24 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		return nil
 	}
 
-	s.logger.Info("Starting worker scheduler", "sync_interval", s.config.SyncInterval)
+	s.logger.Info("Starting worker scheduler", "default_sync_interval", s.config.SyncInterval)
 
-	ticker := time.NewTicker(s.config.SyncInterval)
-	defer ticker.Stop()
+	now := time.Now()
+	s.mu.Lock()
+	for name, entry := range s.jobs {
+		s.scheduleFirst(entry, now)
+		s.logger.Info("Job scheduled", "name", name, "next_run", entry.nextRun)
+	}
+	s.mu.Unlock()
+
+	timer := time.NewTimer(s.untilNextRun(now))
+	defer timer.Stop()
+
+	heartbeat := time.NewTicker(HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	s.beat(now)
+	defer s.heartbeat.Store(0)
 
 	for {
 		select {
@@ -67,12 +201,49 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		case <-s.stopChan:
 			s.logger.Info("Worker scheduler stopping")
 			return nil
-		case <-ticker.C:
-			s.executeJobs(ctx)
+		case <-s.wakeChan:
+		case <-heartbeat.C:
+		case <-timer.C:
+			s.executeDueJobs(ctx, time.Now())
+		}
+		s.beat(time.Now())
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
 		}
+		timer.Reset(s.untilNextRun(time.Now()))
 	}
 }
 
+// LastHeartbeat returns when the scheduling loop last ran, zero when it is not running
+func (s *Scheduler) LastHeartbeat() time.Time {
+	nanos := s.heartbeat.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// CheckAlive returns an error when the scheduling loop is not running or has not run for longer than maxSilence,
+// which is expected to be a few heartbeat intervals
+func (s *Scheduler) CheckAlive(maxSilence time.Duration) error {
+	last := s.LastHeartbeat()
+	if last.IsZero() {
+		return ErrSchedulerNotRunning
+	}
+	if silence := time.Since(last); silence > maxSilence {
+		return fmt.Errorf("scheduler loop stalled, last heartbeat %s ago", silence.Truncate(time.Second))
+	}
+	return nil
+}
+
+func (s *Scheduler) beat(now time.Time) {
+	s.heartbeat.Store(now.UnixNano())
+}
+
 func (s *Scheduler) Stop() {
 	s.mu.Lock()
 	if !s.running {
@@ -87,27 +258,198 @@ func (s *Scheduler) Stop() {
 	s.logger.Info("Worker scheduler stopped")
 }
 
-func (s *Scheduler) executeJobs(ctx context.Context) {
-	s.mu.RLock()
-	jobs := make([]Job, 0, len(s.jobs))
-	for _, job := range s.jobs {
-		jobs = append(jobs, job)
+func (s *Scheduler) executeDueJobs(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, entry := range s.jobs {
+		if entry.nextRun.IsZero() || entry.nextRun.After(now) {
+			continue
+		}
+		slot := entry.slot
+		s.scheduleNext(entry, now)
+		s.logger.Debug("Job rescheduled", "name", name, "next_run", entry.nextRun)
+
+		if !entry.running {
+			s.launch(ctx, entry, slot)
+			continue
+		}
+
+		switch entry.options.Overlap {
+		case entity.OverlapQueue:
+			s.logger.Info("Previous run still in progress, queueing job", "name", name)
+			entry.pending = true
+			entry.pendingSlot = slot
+		case entity.OverlapCancelPrevious:
+			s.logger.Info("Previous run still in progress, cancelling it", "name", name)
+			entry.pending = true
+			entry.pendingSlot = slot
+			entry.cancel(entity.ErrJobSuperseded)
+		default:
+			s.logger.Info("Previous run still in progress, skipping job", "name", name)
+		}
+	}
+}
+
+// launch starts a run of the job, the caller must hold s.mu
+func (s *Scheduler) launch(ctx context.Context, entry *scheduledJob, slot runSlot) {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	entry.running = true
+	entry.cancel = cancel
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if s.locker != nil {
+			s.executeWithLease(jobCtx, entry, slot)
+		} else {
+			s.executeJob(jobCtx, entry.job)
+		}
+		cancel(nil)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		entry.running = false
+		entry.cancel = nil
+		if entry.pending && s.running && ctx.Err() == nil {
+			entry.pending = false
+			s.launch(ctx, entry, entry.pendingSlot)
+		}
+	}()
+}
+
+func (s *Scheduler) scheduleFirst(entry *scheduledJob, now time.Time) {
+	if entry.options.RunOnStartup {
+		entry.slot = startupSlot(entry.options.Schedule, now)
+		entry.nextRun = now
+		return
 	}
-	s.mu.RUnlock()
+	s.scheduleNext(entry, now)
+}
 
-	if len(jobs) == 0 {
-		s.logger.Debug("No jobs registered, skipping execution")
+func (s *Scheduler) scheduleNext(entry *scheduledJob, now time.Time) {
+	entry.slot = runSlot{at: entry.options.Schedule.Next(now)}
+	entry.nextRun = entry.slot.at
+	if entry.slot.at.IsZero() {
+		// The schedule never fires again
 		return
 	}
+	if entry.options.Jitter > 0 {
+		entry.nextRun = entry.slot.at.Add(rand.N(entry.options.Jitter))
+	}
+}
 
-	s.logger.Info("Starting job execution", "job_count", len(jobs))
+// executeWithLease runs the job only if this instance wins the lease for the slot. Instances that lose
+// keep polling until the slot is completed, so they can take over when the holder dies and its lease expires.
+func (s *Scheduler) executeWithLease(ctx context.Context, entry *scheduledJob, slot runSlot) {
+	job := entry.job
+	ttl := s.config.LockTTL
+	key := slot.leaseKey(job.Name())
+	nextSlot := entry.options.Schedule.Next(slot.at)
+
+	var lease Lease
+	for lease == nil {
+		acquired, state, err := s.locker.Acquire(ctx, key, ttl)
+		if err != nil {
+			s.logger.Warn("Job lock unavailable, running without lease", "name", job.Name(), "error", err)
+			s.executeJob(ctx, job)
+			return
+		}
+
+		switch state {
+		case LeaseAcquired:
+			lease = acquired
+			continue
+		case LeaseCompleted:
+			s.logger.Debug("Job slot already run by another instance", "name", job.Name(), "slot", key)
+			return
+		}
+
+		if !nextSlot.IsZero() && !time.Now().Add(ttl).Before(nextSlot) {
+			s.logger.Debug("Job slot held by another instance until the next slot", "name", job.Name(), "slot", key)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-time.After(ttl / 3):
+		}
+	}
+
+	leaseCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	renewDone := make(chan struct{})
+	go func() {
+		defer close(renewDone)
+		s.renewLease(leaseCtx, cancel, lease, job.Name(), ttl)
+	}()
+
+	s.executeJob(leaseCtx, job)
+	cancel(nil)
+	<-renewDone
+
+	// Keep the completed marker until the slot can no longer be picked up by a late instance
+	retain := ttl
+	if !nextSlot.IsZero() {
+		retain += max(time.Until(nextSlot), 0)
+	}
+	if err := lease.Complete(context.WithoutCancel(ctx), retain); err != nil {
+		s.logger.Warn("Failed to mark job slot as completed", "name", job.Name(), "error", err)
+	}
+}
+
+func (s *Scheduler) renewLease(ctx context.Context, cancel context.CancelCauseFunc, lease Lease, name string, ttl time.Duration) {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := lease.Renew(ctx, ttl)
+			if errors.Is(err, entity.ErrLeaseLost) {
+				s.logger.Error("Job lease lost, stopping run", "name", name)
+				cancel(entity.ErrLeaseLost)
+				return
+			}
+			if err != nil {
+				s.logger.Warn("Failed to renew job lease", "name", name, "error", err)
+			}
+		}
+	}
+}
+
+// untilNextRun returns the delay until the earliest scheduled job, idling when nothing is scheduled
+func (s *Scheduler) untilNextRun(now time.Time) time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var earliest time.Time
+	for _, entry := range s.jobs {
+		if entry.nextRun.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || entry.nextRun.Before(earliest) {
+			earliest = entry.nextRun
+		}
+	}
+
+	if earliest.IsZero() {
+		return time.Hour
+	}
+	return max(earliest.Sub(now), 0)
+}
 
-	for _, job := range jobs {
-		s.wg.Add(1)
-		go func(j Job) {
-			defer s.wg.Done()
-			s.executeJob(ctx, j)
-		}(job)
+func (s *Scheduler) wake() {
+	select {
+	case s.wakeChan <- struct{}{}:
+	default:
 	}
 }
 
@@ -0,0 +1,335 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+type countingJob struct {
+	name  string
+	count atomic.Int32
+}
+
+func (j *countingJob) Name() string { return j.name }
+
+func (j *countingJob) Execute(ctx context.Context) error {
+	j.count.Add(1)
+	return nil
+}
+
+func TestJobOptionsFor(t *testing.T) {
+	cfg := config.WorkerConfig{
+		Schedules:    map[string]string{"openweather": "*/5 * * * *", "broken": "nope"},
+		Jitter:       map[string]time.Duration{"openweather": 30 * time.Second},
+		RunOnStartup: []string{"openweather"},
+	}
+
+	options, err := JobOptionsFor(cfg, "openweather")
+	require.NoError(t, err)
+	assert.NotNil(t, options.Schedule)
+	assert.Equal(t, 30*time.Second, options.Jitter)
+	assert.True(t, options.RunOnStartup)
+
+	options, err = JobOptionsFor(cfg, "pokemon")
+	require.NoError(t, err)
+	assert.Nil(t, options.Schedule, "Sources without override should fall back to the sync interval")
+	assert.False(t, options.RunOnStartup)
+
+	_, err = JobOptionsFor(cfg, "broken")
+	assert.Error(t, err)
+}
+
+func TestScheduler_PerJobSchedules(t *testing.T) {
+	scheduler := NewScheduler(config.WorkerConfig{
+		Enabled:      true,
+		SyncInterval: time.Hour,
+		JobTimeout:   time.Second,
+	}, logger.NewLogger(logger.LevelError, "test"), nil)
+
+	fast := &countingJob{name: "fast"}
+	slow := &countingJob{name: "slow"}
+	startup := &countingJob{name: "startup"}
+
+	scheduler.RegisterJob(fast, JobOptions{Schedule: Every(20 * time.Millisecond)})
+	scheduler.RegisterJob(slow, JobOptions{})
+	scheduler.RegisterJob(startup, JobOptions{RunOnStartup: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go scheduler.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		return fast.count.Load() >= 3 && startup.count.Load() == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(0), slow.count.Load(), "Job on the default interval should not have run yet")
+
+	nextHour := time.Now().Truncate(time.Hour).Add(time.Hour)
+	nextRuns := scheduler.NextRuns()
+	assert.WithinDuration(t, nextHour, nextRuns["slow"], time.Second)
+	assert.WithinDuration(t, nextHour, nextRuns["startup"], time.Second)
+
+	scheduler.Stop()
+}
+
+func TestScheduler_CheckAlive(t *testing.T) {
+	scheduler := NewScheduler(config.WorkerConfig{
+		Enabled:      true,
+		SyncInterval: time.Hour,
+		JobTimeout:   time.Second,
+	}, logger.NewLogger(logger.LevelError, "test"), nil)
+
+	assert.ErrorIs(t, scheduler.CheckAlive(time.Minute), ErrSchedulerNotRunning)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.Start(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return scheduler.CheckAlive(time.Minute) == nil
+	}, time.Second, 5*time.Millisecond)
+
+	scheduler.heartbeat.Store(time.Now().Add(-time.Hour).UnixNano())
+	assert.ErrorContains(t, scheduler.CheckAlive(time.Minute), "stalled")
+
+	cancel()
+	<-done
+	assert.ErrorIs(t, scheduler.CheckAlive(time.Minute), ErrSchedulerNotRunning, "A stopped loop should not look alive")
+	assert.True(t, scheduler.LastHeartbeat().IsZero())
+}
+
+type blockingJob struct {
+	name    string
+	started atomic.Int32
+	release chan struct{}
+}
+
+func (j *blockingJob) Name() string { return j.name }
+
+func (j *blockingJob) Execute(ctx context.Context) error {
+	j.started.Add(1)
+	select {
+	case <-j.release:
+	case <-ctx.Done():
+	}
+	return ctx.Err()
+}
+
+func TestScheduler_OverlapPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy entity.OverlapPolicy
+		// runs started while the first run is blocked, and after it is released
+		whileBlocked int32
+		afterRelease int32
+		cancelsFirst bool
+	}{
+		{name: "skip", policy: entity.OverlapSkip, whileBlocked: 1},
+		{name: "queue", policy: entity.OverlapQueue, whileBlocked: 1, afterRelease: 2},
+		{name: "cancel previous", policy: entity.OverlapCancelPrevious, cancelsFirst: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := NewScheduler(config.WorkerConfig{
+				Enabled:      true,
+				SyncInterval: time.Hour,
+				JobTimeout:   time.Minute,
+			}, logger.NewLogger(logger.LevelError, "test"), nil)
+
+			job := &blockingJob{name: "sync", release: make(chan struct{})}
+			scheduler.RegisterJob(job, JobOptions{Schedule: Every(10 * time.Millisecond), Overlap: tt.policy})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go scheduler.Start(ctx)
+
+			if tt.cancelsFirst {
+				// Every tick cancels the running job and starts a new one
+				assert.Eventually(t, func() bool { return job.started.Load() >= 3 }, time.Second, 5*time.Millisecond)
+			} else {
+				assert.Eventually(t, func() bool { return job.started.Load() == 1 }, time.Second, 5*time.Millisecond)
+				time.Sleep(50 * time.Millisecond)
+				assert.Equal(t, tt.whileBlocked, job.started.Load(), "No run should start while the first one is going")
+
+				job.release <- struct{}{}
+				if tt.afterRelease > 0 {
+					assert.Eventually(t, func() bool { return job.started.Load() == tt.afterRelease }, time.Second, 5*time.Millisecond)
+				}
+			}
+
+			close(job.release)
+			scheduler.Stop()
+		})
+	}
+}
+
+// memoryLocker is an in-process Locker shared by several schedulers to simulate replicas
+type memoryLocker struct {
+	mu     sync.Mutex
+	leases map[string]*memoryLease
+}
+
+type memoryLease struct {
+	locker    *memoryLocker
+	key       string
+	expiresAt time.Time
+	completed bool
+}
+
+func newMemoryLocker() *memoryLocker {
+	return &memoryLocker{leases: make(map[string]*memoryLease)}
+}
+
+func (l *memoryLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lease, LeaseState, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if current, ok := l.leases[key]; ok && time.Now().Before(current.expiresAt) {
+		if current.completed {
+			return nil, LeaseCompleted, nil
+		}
+		return nil, LeaseHeld, nil
+	}
+
+	lease := &memoryLease{locker: l, key: key, expiresAt: time.Now().Add(ttl)}
+	l.leases[key] = lease
+	return lease, LeaseAcquired, nil
+}
+
+// expire simulates the holder dying: the lease stops being renewed and runs out
+func (l *memoryLocker) expire(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.leases, key)
+}
+
+func (l *memoryLease) Renew(ctx context.Context, ttl time.Duration) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+
+	if l.locker.leases[l.key] != l {
+		return entity.ErrLeaseLost
+	}
+	l.expiresAt = time.Now().Add(ttl)
+	return nil
+}
+
+func (l *memoryLease) Complete(ctx context.Context, retain time.Duration) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+
+	if l.locker.leases[l.key] != l {
+		return entity.ErrLeaseLost
+	}
+	l.completed = true
+	l.expiresAt = time.Now().Add(retain)
+	return nil
+}
+
+func TestScheduler_LeaseAllowsOneInstancePerSlot(t *testing.T) {
+	locker := newMemoryLocker()
+	cfg := config.WorkerConfig{
+		Enabled:      true,
+		SyncInterval: time.Hour,
+		JobTimeout:   time.Minute,
+		LockTTL:      30 * time.Millisecond,
+	}
+
+	// Two replicas register the same job
+	first := &countingJob{name: "sync"}
+	second := &countingJob{name: "sync"}
+	replicas := []*Scheduler{
+		NewScheduler(cfg, logger.NewLogger(logger.LevelError, "test"), locker),
+		NewScheduler(cfg, logger.NewLogger(logger.LevelError, "test"), locker),
+	}
+	replicas[0].RegisterJob(first, JobOptions{RunOnStartup: true})
+	replicas[1].RegisterJob(second, JobOptions{RunOnStartup: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, scheduler := range replicas {
+		go scheduler.Start(ctx)
+	}
+
+	assert.Eventually(t, func() bool { return first.count.Load()+second.count.Load() == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), first.count.Load()+second.count.Load(), "Only one replica should run the startup slot")
+
+	for _, scheduler := range replicas {
+		scheduler.Stop()
+	}
+}
+
+// fixedSchedule fires once at the given time
+type fixedSchedule struct {
+	at time.Time
+}
+
+func (s fixedSchedule) Next(after time.Time) time.Time {
+	if after.Before(s.at) {
+		return s.at
+	}
+	return time.Time{}
+}
+
+func TestStartupSlot(t *testing.T) {
+	schedule := Every(time.Hour)
+	start := time.Date(2024, 1, 15, 10, 5, 0, 0, time.UTC)
+
+	// Replicas starting minutes apart within the same period share the startup lease
+	first := startupSlot(schedule, start)
+	second := startupSlot(schedule, start.Add(40*time.Minute))
+	assert.Equal(t, first.leaseKey("sync"), second.leaseKey("sync"))
+
+	// The next period has its own startup run, and the startup run does not take the regular run's lease
+	assert.NotEqual(t, first.leaseKey("sync"), startupSlot(schedule, start.Add(time.Hour)).leaseKey("sync"))
+	regular := runSlot{at: schedule.Next(start)}
+	assert.NotEqual(t, first.leaseKey("sync"), regular.leaseKey("sync"))
+}
+
+func TestScheduler_LeaseFailover(t *testing.T) {
+	locker := newMemoryLocker()
+	cfg := config.WorkerConfig{
+		Enabled:      true,
+		SyncInterval: time.Hour,
+		JobTimeout:   time.Minute,
+		LockTTL:      30 * time.Millisecond,
+	}
+
+	// Another instance holds the startup slot and dies without renewing it. The slot is taken from the schedule
+	// the scheduler uses, a fixed one so that it cannot move while the test runs.
+	schedule := fixedSchedule{at: time.Now().Add(time.Hour)}
+	key := startupSlot(schedule, time.Now()).leaseKey("sync")
+	_, state, err := locker.Acquire(context.Background(), key, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, LeaseAcquired, state)
+
+	job := &countingJob{name: "sync"}
+	scheduler := NewScheduler(cfg, logger.NewLogger(logger.LevelError, "test"), locker)
+	scheduler.RegisterJob(job, JobOptions{Schedule: schedule, RunOnStartup: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.Start(ctx)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(0), job.count.Load(), "Slot held by another instance should not run")
+
+	locker.expire(key)
+	assert.Eventually(t, func() bool { return job.count.Load() == 1 }, time.Second, 5*time.Millisecond, "Standby should take over the expired slot")
+
+	scheduler.Stop()
+}
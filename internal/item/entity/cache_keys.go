@@ -0,0 +1,22 @@
+package entity
+
+import "fmt"
+
+// ItemListCacheKey is the cache key of one page of an item listing. Listings of soft-deleted items are cached apart.
+func ItemListCacheKey(apiSource, status string, deleted bool, limit, offset int) string {
+	if deleted {
+		return fmt.Sprintf("items:deleted:%s:%d:%d", apiSource, limit, offset)
+	}
+	return fmt.Sprintf("items:%s:%s:%d:%d", apiSource, status, limit, offset)
+}
+
+// ItemListCachePatterns match every cached listing that may hold items of apiSource, the listings that are not
+// filtered by source included
+func ItemListCachePatterns(apiSource string) []string {
+	return []string{
+		fmt.Sprintf("items:%s:*", apiSource),
+		"items::*",
+		fmt.Sprintf("items:deleted:%s:*", apiSource),
+		"items:deleted::*",
+	}
+}
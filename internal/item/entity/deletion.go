@@ -0,0 +1,27 @@
+package entity
+
+import "fmt"
+
+// DeletionPolicy decides what a complete sync does with stored items its source no longer returns
+type DeletionPolicy string
+
+const (
+	// DeletionHardDelete removes the item rows together with their versions
+	DeletionHardDelete DeletionPolicy = "hard-delete"
+	// DeletionSoftDelete sets deleted_at, soft-deleted items are hidden from listings
+	DeletionSoftDelete DeletionPolicy = "soft-delete"
+	// DeletionFlagOnly sets stale_at and keeps the item listed
+	DeletionFlagOnly DeletionPolicy = "flag-only"
+)
+
+// ParseDeletionPolicy parses a policy name, an empty value means DeletionFlagOnly
+func ParseDeletionPolicy(value string) (DeletionPolicy, error) {
+	switch policy := DeletionPolicy(value); policy {
+	case "":
+		return DeletionFlagOnly, nil
+	case DeletionHardDelete, DeletionSoftDelete, DeletionFlagOnly:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown deletion policy %q", value)
+	}
+}
@@ -16,13 +16,20 @@ type Item struct {
 	SyncedAt    time.Time              `json:"synced_at" db:"last_synced_at" example:"2024-01-15T10:30:00Z" description:"Last sync timestamp"`
 	CreatedAt   time.Time              `json:"created_at" db:"created_at" example:"2024-01-15T10:00:00Z" description:"Creation timestamp"`
 	UpdatedAt   time.Time              `json:"updated_at" db:"updated_at" example:"2024-01-15T10:30:00Z" description:"Last update timestamp"`
+	DeletedAt   *time.Time             `json:"deleted_at,omitempty" db:"deleted_at" example:"2024-01-16T10:30:00Z" description:"Time the item was soft-deleted after disappearing upstream"`
+	StaleAt     *time.Time             `json:"stale_at,omitempty" db:"stale_at" example:"2024-01-16T10:30:00Z" description:"Time a complete sync first missed the item upstream"`
 }
 
 // ExternalItem represents an item from external API before transformation
 type ExternalItem struct {
-	ID         int                    `json:"id" example:"25" description:"External API item ID"`
-	Title      string                 `json:"title" example:"Pikachu" description:"External API item title"`
-	ExtendInfo map[string]interface{} `json:"extend_info" description:"Raw data from external API"`
+	ID          int                    `json:"id" example:"25" description:"External API item ID"`
+	Title       string                 `json:"title" example:"Pikachu" description:"External API item title"`
+	Description string                 `json:"description,omitempty" example:"Electric pokemon, 0.4 m, 6.0 kg" description:"External API item description"`
+	ExtendInfo  map[string]interface{} `json:"extend_info" description:"Raw data from external API"`
+
+	// Incomplete is set on items missing data their source normally provides, such as a pokemon whose detail could
+	// not be fetched. They count as seen upstream but are not stored over the complete version.
+	Incomplete bool `json:"-"`
 }
 
 func (i *Item) Validate() error {
@@ -39,6 +46,7 @@ func (i *Item) Validate() error {
 func (i *Item) FromAPIResponse(apiSource string, extItem ExternalItem) {
 	i.ExternalID = extItem.ID
 	i.Title = extItem.Title
+	i.Description = extItem.Description
 	i.APISource = apiSource
 	i.ExtendInfo = extItem.ExtendInfo
 	i.SyncedAt = time.Now()
@@ -51,3 +59,20 @@ func NewItem() Item {
 		UpdatedAt: now,
 	}
 }
+
+// UpsertOutcome classifies what a hash-based upsert did with an external item
+type UpsertOutcome string
+
+const (
+	UpsertInserted  UpsertOutcome = "inserted"
+	UpsertUpdated   UpsertOutcome = "updated"
+	UpsertUnchanged UpsertOutcome = "unchanged"
+	UpsertFailed    UpsertOutcome = "failed"
+)
+
+// UpsertResult is the outcome of upserting a single external item
+type UpsertResult struct {
+	ExternalID int
+	Outcome    UpsertOutcome
+	Err        error
+}
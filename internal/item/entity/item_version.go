@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// ItemSnapshot is the stored content of an item at one point in time
+type ItemSnapshot struct {
+	Title      string                 `json:"title" example:"Pikachu" description:"Item title/name"`
+	ExtendInfo map[string]interface{} `json:"extend_info" description:"Additional data from external API"`
+}
+
+// ItemVersion records one real content change of an item stored in item_versions
+type ItemVersion struct {
+	ID           int64         `json:"id" db:"id" example:"7" description:"Version ID"`
+	ItemID       int           `json:"item_id" db:"item_id" example:"1" description:"Internal database ID of the item"`
+	SyncJobID    *int64        `json:"sync_job_id,omitempty" db:"sync_job_id" example:"42" description:"Sync job that made the change"`
+	PreviousHash string        `json:"previous_hash,omitempty" db:"previous_hash" description:"Content hash before the change, empty for the first version"`
+	ContentHash  string        `json:"content_hash" db:"content_hash" description:"Content hash after the change"`
+	OldPayload   *ItemSnapshot `json:"old_payload,omitempty" db:"old_payload" description:"Content before the change, absent for the first version"`
+	NewPayload   ItemSnapshot  `json:"new_payload" db:"new_payload" description:"Content after the change"`
+	CreatedAt    time.Time     `json:"created_at" db:"created_at" example:"2024-01-15T10:30:00Z" description:"Time the change was stored"`
+}
+
+// FieldChange is a single difference between two item snapshots. Path uses dots for nested extend_info keys
+type FieldChange struct {
+	Path     string      `json:"path" example:"extend_info.weight" description:"Changed field"`
+	Type     string      `json:"type" example:"changed" description:"Kind of change (added, removed, changed)"`
+	OldValue interface{} `json:"old_value,omitempty" description:"Value in the older version"`
+	NewValue interface{} `json:"new_value,omitempty" description:"Value in the newer version"`
+}
+
+const (
+	FieldAdded   = "added"
+	FieldRemoved = "removed"
+	FieldChanged = "changed"
+)
+
+type syncJobIDKey struct{}
+
+// WithSyncJobID attaches the ID of the running sync job so item versions written on its behalf reference it
+func WithSyncJobID(ctx context.Context, jobID int64) context.Context {
+	return context.WithValue(ctx, syncJobIDKey{}, jobID)
+}
+
+// SyncJobIDFromContext returns the sync job ID attached by WithSyncJobID
+func SyncJobIDFromContext(ctx context.Context) (int64, bool) {
+	jobID, ok := ctx.Value(syncJobIDKey{}).(int64)
+	return jobID, ok
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OverlapPolicy decides what happens when a sync of a source is due while its previous run is still going
+type OverlapPolicy string
+
+const (
+	// OverlapSkip drops the new run
+	OverlapSkip OverlapPolicy = "skip"
+	// OverlapQueue starts the new run once the previous one finishes
+	OverlapQueue OverlapPolicy = "queue"
+	// OverlapCancelPrevious cancels the previous run and starts the new one when it has stopped
+	OverlapCancelPrevious OverlapPolicy = "cancel-previous"
+)
+
+// Cancellation causes of scheduled runs, a run stopped by either is recorded as cancelled rather than failed
+var (
+	// ErrJobSuperseded is the cause of a run replaced under OverlapCancelPrevious
+	ErrJobSuperseded = errors.New("job superseded by a newer run")
+	// ErrLeaseLost is the cause of a run whose lease expired or was taken over by another instance
+	ErrLeaseLost = errors.New("job lease lost")
+)
+
+// ParseOverlapPolicy parses a policy name, an empty value means OverlapSkip
+func ParseOverlapPolicy(value string) (OverlapPolicy, error) {
+	switch policy := OverlapPolicy(value); policy {
+	case "":
+		return OverlapSkip, nil
+	case OverlapSkip, OverlapQueue, OverlapCancelPrevious:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown overlap policy %q", value)
+	}
+}
@@ -0,0 +1,114 @@
+package entity
+
+import "time"
+
+const (
+	SyncJobStatusRunning   = "running"
+	SyncJobStatusCompleted = "completed"
+	SyncJobStatusFailed    = "failed"
+	SyncJobStatusCancelled = "cancelled"
+)
+
+// SyncJobRecord represents a single execution of a sync job stored in sync_jobs
+type SyncJobRecord struct {
+	ID               int64      `json:"id" db:"id" example:"42" description:"Sync job ID"`
+	JobName          string     `json:"job_name" db:"job_name" example:"manual_sync" description:"Name of the job that created the record"`
+	APISource        string     `json:"api_source" db:"api_source" example:"pokemon" description:"Source API (pokemon, openweather)"`
+	Status           string     `json:"status" db:"status" example:"completed" description:"Job status (running, completed, failed, cancelled)"`
+	StartedAt        time.Time  `json:"started_at" db:"started_at" example:"2024-01-15T10:30:00Z" description:"Job start timestamp"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty" db:"completed_at" example:"2024-01-15T10:31:00Z" description:"Job completion timestamp"`
+	ItemsProcessed   int        `json:"items_processed" db:"items_processed" example:"1302" description:"Number of items processed"`
+	ItemsSucceeded   int        `json:"items_succeeded" db:"items_succeeded" example:"1300" description:"Number of items stored successfully"`
+	ItemsFailed      int        `json:"items_failed" db:"items_failed" example:"2" description:"Number of items that failed to store"`
+	ItemsInserted    int        `json:"items_inserted" db:"items_inserted" example:"12" description:"Number of items that were new"`
+	ItemsUpdated     int        `json:"items_updated" db:"items_updated" example:"30" description:"Number of stored items whose content changed"`
+	ItemsUnchanged   int        `json:"items_unchanged" db:"items_unchanged" example:"1258" description:"Number of stored items whose content was identical"`
+	PagesNotModified int        `json:"pages_not_modified" db:"pages_not_modified" example:"60" description:"Number of pages skipped because the upstream reported them not modified"`
+	ErrorMessage     string     `json:"error_message,omitempty" db:"error_message" description:"Last error reported by the job"`
+	ExecutionTimeMs  int64      `json:"execution_time_ms" db:"execution_time_ms" example:"61234" description:"Job execution time in milliseconds"`
+}
+
+// SyncJobStats are the item counters of a sync job run
+type SyncJobStats struct {
+	Processed int
+	Succeeded int
+	Failed    int
+	Inserted  int
+	Updated   int
+	Unchanged int
+
+	// Pages the upstream answered with 304 Not Modified, their items are not counted above
+	PagesNotModified int
+}
+
+// Add counts the outcome of one upserted item
+func (s *SyncJobStats) Add(result UpsertResult) {
+	s.Processed++
+
+	switch result.Outcome {
+	case UpsertFailed:
+		s.Failed++
+		return
+	case UpsertInserted:
+		s.Inserted++
+	case UpsertUpdated:
+		s.Updated++
+	case UpsertUnchanged:
+		s.Unchanged++
+	}
+	s.Succeeded++
+}
+
+// Merge adds the counters of another run segment, such as a single page
+func (s *SyncJobStats) Merge(other SyncJobStats) {
+	s.Processed += other.Processed
+	s.Succeeded += other.Succeeded
+	s.Failed += other.Failed
+	s.Inserted += other.Inserted
+	s.Updated += other.Updated
+	s.Unchanged += other.Unchanged
+	s.PagesNotModified += other.PagesNotModified
+}
+
+// SyncCheckpoint is the cursor state of an unfinished full sync pass stored in sync_checkpoints.
+// A sync of the same source resumes from it and deletes it once a pass completes. Watermark is the newest upstream
+// Last-Modified of the pages the pass stored, a resumed pass that finds a page modified after it starts over since
+// the listing may have shifted under the saved position.
+type SyncCheckpoint struct {
+	APISource string     `json:"api_source" db:"api_source"`
+	Offset    int        `json:"offset" db:"cursor_offset"`
+	NextURL   string     `json:"next_url,omitempty" db:"next_url"`
+	Cursor    string     `json:"cursor,omitempty" db:"cursor"`
+	Watermark *time.Time `json:"watermark,omitempty" db:"watermark"`
+	SyncJobID int64      `json:"sync_job_id" db:"sync_job_id"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsZero reports whether the checkpoint points at the start of a pass
+func (c SyncCheckpoint) IsZero() bool {
+	return c.Offset == 0 && c.NextURL == "" && c.Cursor == ""
+}
+
+// AdvanceWatermark moves the watermark to lastModified when it is newer, zero times are ignored
+func (c *SyncCheckpoint) AdvanceWatermark(lastModified time.Time) {
+	if lastModified.IsZero() || (c.Watermark != nil && !lastModified.After(*c.Watermark)) {
+		return
+	}
+	lastModified = lastModified.UTC()
+	c.Watermark = &lastModified
+}
+
+// ModifiedSince reports whether lastModified is after the watermark, never when either is unknown
+func (c SyncCheckpoint) ModifiedSince(lastModified time.Time) bool {
+	return c.Watermark != nil && !lastModified.IsZero() && lastModified.After(*c.Watermark)
+}
+
+// SyncJobFilter narrows down the sync job records returned by a listing
+type SyncJobFilter struct {
+	APISource     string
+	Status        string
+	StartedAfter  time.Time
+	StartedBefore time.Time
+	Limit         int
+	Offset        int
+}
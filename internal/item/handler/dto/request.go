@@ -28,3 +28,40 @@ func (r GetItemsRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// GetSyncJobsRequest represents the query parameters for listing sync jobs
+type GetSyncJobsRequest struct {
+	APISource string `json:"api_source" query:"api_source" example:"pokemon" description:"Filter by API source"`
+	Status    string `json:"status" query:"status" validate:"omitempty,oneof=running completed failed cancelled" example:"completed" description:"Filter by status"`
+	From      string `json:"from" query:"from" example:"2024-01-15T00:00:00Z" description:"Only jobs started at or after this RFC3339 timestamp"`
+	To        string `json:"to" query:"to" example:"2024-01-16T00:00:00Z" description:"Only jobs started at or before this RFC3339 timestamp"`
+	Limit     int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100" example:"20" description:"Number of jobs to return (max 100)"`
+	Offset    int    `json:"offset" query:"offset" validate:"omitempty,min=0" example:"0" description:"Number of jobs to skip for pagination"`
+}
+
+func (r GetSyncJobsRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// GetItemHistoryRequest represents the query parameters for listing item versions
+type GetItemHistoryRequest struct {
+	Limit  int `json:"limit" query:"limit" validate:"omitempty,min=1,max=100" example:"20" description:"Number of versions to return (max 100)"`
+	Offset int `json:"offset" query:"offset" validate:"omitempty,min=0" example:"0" description:"Number of versions to skip for pagination"`
+}
+
+func (r GetItemHistoryRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// GetItemDiffRequest represents the query parameters for diffing two item versions
+type GetItemDiffRequest struct {
+	From int64 `json:"from" query:"from" validate:"required,min=1" example:"3" description:"ID of the older version"`
+	To   int64 `json:"to" query:"to" validate:"required,min=1" example:"7" description:"ID of the newer version"`
+}
+
+func (r GetItemDiffRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
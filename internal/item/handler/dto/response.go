@@ -2,10 +2,13 @@ package dto
 
 import (
 	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/circuit"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
 )
 
 // SyncItemsResponse represents the response from syncing items
 type SyncItemsResponse struct {
+	JobID   int64    `json:"job_id" example:"42" description:"ID of the sync job that was started"`
 	Errors  []string `json:"errors,omitempty" description:"List of error messages for failed items"`
 	Status  string   `json:"status"`
 	Message string   `json:"message"`
@@ -17,6 +20,52 @@ type GetItemsResponse struct {
 	Total int           `json:"total" example:"150" description:"Total number of items matching the query"`
 }
 
+// GetSyncJobsResponse represents the response from listing sync jobs
+type GetSyncJobsResponse struct {
+	Jobs  []entity.SyncJobRecord `json:"jobs" description:"List of sync jobs"`
+	Total int                    `json:"total" example:"10" description:"Total number of sync jobs matching the query"`
+}
+
+// GetSyncJobResponse represents the response from fetching a single sync job
+type GetSyncJobResponse struct {
+	Job entity.SyncJobRecord `json:"job" description:"Sync job details"`
+}
+
+// CancelSyncJobResponse represents the response from cancelling a sync job
+type CancelSyncJobResponse struct {
+	JobID   int64  `json:"job_id" example:"42" description:"ID of the cancelled sync job"`
+	Status  string `json:"status" example:"cancelled" description:"Status the job record will end with"`
+	Message string `json:"message" example:"Sync job cancellation has been requested"`
+}
+
+// GetItemHistoryResponse represents the response from listing the versions of an item
+type GetItemHistoryResponse struct {
+	Versions []entity.ItemVersion `json:"versions" description:"Item versions, newest first"`
+	Total    int                  `json:"total" example:"12" description:"Total number of versions of the item"`
+}
+
+// GetItemDiffResponse represents the response from diffing two item versions
+type GetItemDiffResponse struct {
+	From    entity.ItemVersion   `json:"from" description:"Older version"`
+	To      entity.ItemVersion   `json:"to" description:"Newer version"`
+	Changes []entity.FieldChange `json:"changes" description:"Fields that differ between the two versions"`
+}
+
+// GetRateLimitsResponse represents the response from listing the outbound rate limiters
+type GetRateLimitsResponse struct {
+	Limiters []ratelimit.State `json:"limiters" description:"One limiter per external API, ordered by name"`
+}
+
+// GetBreakersResponse represents the response from listing the circuit breakers
+type GetBreakersResponse struct {
+	Breakers []circuit.Snapshot `json:"breakers" description:"One breaker per external API called so far, ordered by name"`
+}
+
+// BreakerActionResponse represents the response from a manual circuit breaker action
+type BreakerActionResponse struct {
+	Breaker circuit.Snapshot `json:"breaker" description:"Breaker state after the action"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Code    string      `json:"code" example:"VALIDATION_ERROR" description:"Error code"`
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zainokta/item-sync/internal/item/handler/dto"
+	"github.com/zainokta/item-sync/internal/item/usecase"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+type ItemHistoryHandler struct {
+	historyUseCase *usecase.ItemHistoryUseCase
+	diffUseCase    *usecase.ItemDiffUseCase
+	logger         logger.Logger
+}
+
+func NewItemHistoryHandler(historyUseCase *usecase.ItemHistoryUseCase, diffUseCase *usecase.ItemDiffUseCase, logger logger.Logger) *ItemHistoryHandler {
+	return &ItemHistoryHandler{
+		historyUseCase: historyUseCase,
+		diffUseCase:    diffUseCase,
+		logger:         logger,
+	}
+}
+
+// GetItemHistory godoc
+// @Summary      Get item change history
+// @Description  Page through the recorded versions of an item, newest first. A version is stored every time a sync changes the item content
+// @Tags         items
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Item ID" minimum(1)
+// @Param        limit query int false "Number of versions to return (default: 20, max: 100)" minimum(1) maximum(100) default(20)
+// @Param        offset query int false "Number of versions to skip (default: 0)" minimum(0) default(0)
+// @Success      200 {object} dto.GetItemHistoryResponse "Item versions with total count"
+// @Failure      400 {object} dto.ErrorResponse "Invalid ID or query parameters"
+// @Failure      404 {object} dto.ErrorResponse "Item not found"
+// @Failure      500 {object} dto.ErrorResponse "Internal server error"
+// @Router       /items/{id}/history [get]
+func (h *ItemHistoryHandler) GetItemHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "invalid ID format",
+		})
+	}
+
+	var req dto.GetItemHistoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+	}
+
+	response, err := h.historyUseCase.Execute(c.Request().Context(), usecase.ItemHistoryRequest{
+		ItemID: id,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		h.logger.Error("Get item history failed", "error", err.Error(), "id", id)
+		return ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.GetItemHistoryResponse{
+		Versions: response.Versions,
+		Total:    response.TotalCount,
+	})
+}
+
+// GetItemDiff godoc
+// @Summary      Diff two item versions
+// @Description  Compare the item content stored by two versions. Nested extend_info keys are reported with dotted paths
+// @Tags         items
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Item ID" minimum(1)
+// @Param        from query int true "ID of the older version" minimum(1)
+// @Param        to query int true "ID of the newer version" minimum(1)
+// @Success      200 {object} dto.GetItemDiffResponse "Both versions and the fields that differ"
+// @Failure      400 {object} dto.ErrorResponse "Invalid ID or query parameters"
+// @Failure      404 {object} dto.ErrorResponse "Version not found"
+// @Failure      500 {object} dto.ErrorResponse "Internal server error"
+// @Router       /items/{id}/diff [get]
+func (h *ItemHistoryHandler) GetItemDiff(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "invalid ID format",
+		})
+	}
+
+	var req dto.GetItemDiffRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+	}
+
+	response, err := h.diffUseCase.Execute(c.Request().Context(), usecase.ItemDiffRequest{
+		ItemID: id,
+		From:   req.From,
+		To:     req.To,
+	})
+	if err != nil {
+		h.logger.Error("Get item diff failed", "error", err.Error(), "id", id, "from", req.From, "to", req.To)
+		return ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.GetItemDiffResponse{
+		From:    response.From,
+		To:      response.To,
+		Changes: response.Changes,
+	})
+}
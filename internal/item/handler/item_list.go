@@ -1,12 +1,10 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
-	pkgErrors "github.com/zainokta/item-sync/internal/errors"
 	"github.com/zainokta/item-sync/internal/item/handler/dto"
 	"github.com/zainokta/item-sync/internal/item/usecase"
 	"github.com/zainokta/item-sync/pkg/logger"
@@ -26,7 +24,7 @@ func NewListHandler(listUseCase *usecase.ListItemsUseCase, logger logger.Logger)
 
 // ListItems godoc
 // @Summary      List items with pagination and filtering
-// @Description  Retrieve a paginated list of items with optional filtering by type, status, and API source
+// @Description  Retrieve a paginated list of items with optional filtering by type, status, and API source. Soft-deleted items are hidden unless deleted=true
 // @Tags         items
 // @Accept       json
 // @Produce      json
@@ -35,6 +33,7 @@ func NewListHandler(listUseCase *usecase.ListItemsUseCase, logger logger.Logger)
 // @Param        item_type query string false "Filter by item type"
 // @Param        status query string false "Filter by status" Enums(pending, completed, failed)
 // @Param        api_source query string false "Filter by API source" Enums(pokemon, openweather)
+// @Param        deleted query bool false "List only items soft-deleted after disappearing upstream (default: false)"
 // @Success      200 {object} dto.GetItemsResponse "List of items with total count"
 // @Failure      400 {object} dto.ErrorResponse "Invalid query parameters"
 // @Failure      500 {object} dto.ErrorResponse "Internal server error"
@@ -53,6 +52,7 @@ func (h *ListHandler) ListItems(c echo.Context) error {
 	itemType := c.QueryParam("item_type")
 	status := c.QueryParam("status")
 	apiSource := c.QueryParam("api_source")
+	deleted, _ := strconv.ParseBool(c.QueryParam("deleted"))
 
 	ctx := c.Request().Context()
 	response, err := h.listUseCase.Execute(ctx, usecase.ListItemsRequest{
@@ -61,24 +61,12 @@ func (h *ListHandler) ListItems(c echo.Context) error {
 		ItemType:  itemType,
 		Status:    status,
 		APISource: apiSource,
+		Deleted:   deleted,
 	})
 
 	if err != nil {
 		h.logger.Error("List items failed", "error", err.Error())
-
-		var domainErr *pkgErrors.DomainError
-		if errors.As(err, &domainErr) {
-			return c.JSON(getHTTPStatusFromError(domainErr), dto.ErrorResponse{
-				Code:    domainErr.Code,
-				Message: domainErr.Message,
-				Details: domainErr.Details,
-			})
-		}
-
-		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Code:    "INTERNAL_ERROR",
-			Message: "Internal server error",
-		})
+		return ErrorResponse(c, err)
 	}
 
 	h.logger.Info("List items completed",
@@ -32,6 +32,7 @@ func NewSyncHandler(syncUseCase *usecase.SyncItemsUseCase, logger logger.Logger)
 // @Param        request body dto.SyncItemsRequest true "Sync request parameters"
 // @Success      200 {object} dto.SyncItemsResponse "Successfully synced items"
 // @Failure      400 {object} dto.ErrorResponse "Invalid request or validation error"
+// @Failure      409 {object} dto.ErrorResponse "A sync job is already running for the source, details carry running_job_id"
 // @Failure      502 {object} dto.ErrorResponse "External API error"
 // @Failure      500 {object} dto.ErrorResponse "Internal server error"
 // @Router       /sync [post]
@@ -62,37 +63,46 @@ func (h *SyncHandler) SyncItems(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Error("Sync failed", "error", err.Error())
-
-		var domainErr *pkgErrors.DomainError
-		if errors.As(err, &domainErr) {
-			return c.JSON(getHTTPStatusFromError(domainErr), dto.ErrorResponse{
-				Code:    domainErr.Code,
-				Message: domainErr.Message,
-				Details: domainErr.Details,
-			})
-		}
-
-		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Code:    "INTERNAL_ERROR",
-			Message: "Internal server error",
-		})
+		return ErrorResponse(c, err)
 	}
 
-	h.logger.Info("Sync completed")
+	h.logger.Info("Sync accepted", "job_id", response.JobID)
 
 	return c.JSON(http.StatusOK, dto.SyncItemsResponse{
+		JobID:   response.JobID,
 		Errors:  response.Errors,
 		Status:  response.Status,
 		Message: response.Message,
 	})
 }
 
+// ErrorResponse answers with the status and code of a domain error, any other error is reported as an internal error
+func ErrorResponse(c echo.Context, err error) error {
+	var domainErr *pkgErrors.DomainError
+	if errors.As(err, &domainErr) {
+		return c.JSON(getHTTPStatusFromError(domainErr), dto.ErrorResponse{
+			Code:    domainErr.Code,
+			Message: domainErr.Message,
+			Details: domainErr.Details,
+		})
+	}
+
+	return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+		Code:    "INTERNAL_ERROR",
+		Message: "Internal server error",
+	})
+}
+
 func getHTTPStatusFromError(err *pkgErrors.DomainError) int {
 	switch err.Category {
 	case pkgErrors.CategoryValidation:
 		return http.StatusBadRequest
 	case pkgErrors.CategoryNotFound:
 		return http.StatusNotFound
+	case pkgErrors.CategoryConflict:
+		return http.StatusConflict
+	case pkgErrors.CategoryUnauthorized:
+		return http.StatusUnauthorized
 	case pkgErrors.CategoryExternalAPI:
 		return http.StatusBadGateway
 	case pkgErrors.CategoryCache:
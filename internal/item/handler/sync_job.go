@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zainokta/item-sync/internal/item/handler/dto"
+	"github.com/zainokta/item-sync/internal/item/usecase"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+type SyncJobHandler struct {
+	listUseCase   *usecase.ListSyncJobsUseCase
+	fetchUseCase  *usecase.FetchSyncJobUseCase
+	cancelUseCase *usecase.CancelSyncJobUseCase
+	logger        logger.Logger
+}
+
+func NewSyncJobHandler(listUseCase *usecase.ListSyncJobsUseCase, fetchUseCase *usecase.FetchSyncJobUseCase, cancelUseCase *usecase.CancelSyncJobUseCase, logger logger.Logger) *SyncJobHandler {
+	return &SyncJobHandler{
+		listUseCase:   listUseCase,
+		fetchUseCase:  fetchUseCase,
+		cancelUseCase: cancelUseCase,
+		logger:        logger,
+	}
+}
+
+// ListSyncJobs godoc
+// @Summary      List sync jobs
+// @Description  Retrieve sync job executions with optional filtering by API source, status and start time range
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        limit query int false "Number of jobs to return (default: 20, max: 100)" minimum(1) maximum(100) default(20)
+// @Param        offset query int false "Number of jobs to skip (default: 0)" minimum(0) default(0)
+// @Param        api_source query string false "Filter by API source" Enums(pokemon, openweather)
+// @Param        status query string false "Filter by status" Enums(running, completed, failed, cancelled)
+// @Param        from query string false "Only jobs started at or after this RFC3339 timestamp"
+// @Param        to query string false "Only jobs started at or before this RFC3339 timestamp"
+// @Success      200 {object} dto.GetSyncJobsResponse "List of sync jobs with total count"
+// @Failure      400 {object} dto.ErrorResponse "Invalid query parameters"
+// @Failure      500 {object} dto.ErrorResponse "Internal server error"
+// @Router       /sync/jobs [get]
+func (h *SyncJobHandler) ListSyncJobs(c echo.Context) error {
+	var req dto.GetSyncJobsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Invalid query parameters",
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+	}
+
+	from, err := parseTimeParam(req.From)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid from timestamp, expected RFC3339",
+		})
+	}
+
+	to, err := parseTimeParam(req.To)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid to timestamp, expected RFC3339",
+		})
+	}
+
+	response, err := h.listUseCase.Execute(c.Request().Context(), usecase.ListSyncJobsRequest{
+		APISource: req.APISource,
+		Status:    req.Status,
+		From:      from,
+		To:        to,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	})
+	if err != nil {
+		h.logger.Error("List sync jobs failed", "error", err.Error())
+		return ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.GetSyncJobsResponse{
+		Jobs:  response.Jobs,
+		Total: response.TotalCount,
+	})
+}
+
+// GetSyncJob godoc
+// @Summary      Get sync job by ID
+// @Description  Retrieve status and item counters of a single sync job
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Sync job ID" minimum(1)
+// @Success      200 {object} dto.GetSyncJobResponse "Sync job details"
+// @Failure      400 {object} dto.ErrorResponse "Invalid ID format"
+// @Failure      404 {object} dto.ErrorResponse "Sync job not found"
+// @Failure      500 {object} dto.ErrorResponse "Internal server error"
+// @Router       /sync/jobs/{id} [get]
+func (h *SyncJobHandler) GetSyncJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "invalid ID format",
+		})
+	}
+
+	response, err := h.fetchUseCase.Execute(c.Request().Context(), usecase.FetchSyncJobRequest{ID: id})
+	if err != nil {
+		h.logger.Error("Get sync job failed", "error", err.Error(), "id", id)
+		return ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.GetSyncJobResponse{
+		Job: response.Job,
+	})
+}
+
+// CancelSyncJob godoc
+// @Summary      Cancel a running sync job
+// @Description  Stop a running sync job. The job record ends with status "cancelled" and keeps its partial item counters
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Sync job ID" minimum(1)
+// @Success      202 {object} dto.CancelSyncJobResponse "Cancellation requested"
+// @Failure      400 {object} dto.ErrorResponse "Invalid ID format"
+// @Failure      404 {object} dto.ErrorResponse "Sync job not found"
+// @Failure      409 {object} dto.ErrorResponse "Sync job is not running"
+// @Failure      500 {object} dto.ErrorResponse "Internal server error"
+// @Router       /sync/jobs/{id} [delete]
+func (h *SyncJobHandler) CancelSyncJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "invalid ID format",
+		})
+	}
+
+	response, err := h.cancelUseCase.Execute(c.Request().Context(), usecase.CancelSyncJobRequest{ID: id})
+	if err != nil {
+		h.logger.Error("Cancel sync job failed", "error", err.Error(), "id", id)
+		return ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, dto.CancelSyncJobResponse{
+		JobID:   response.JobID,
+		Status:  response.Status,
+		Message: response.Message,
+	})
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
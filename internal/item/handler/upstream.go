@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zainokta/item-sync/internal/item/handler/dto"
+	"github.com/zainokta/item-sync/internal/item/usecase"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+// UpstreamHandler exposes the state of the clients talking to external APIs
+type UpstreamHandler struct {
+	rateLimitsUseCase    *usecase.ListRateLimitsUseCase
+	breakersUseCase      *usecase.ListBreakersUseCase
+	breakerActionUseCase *usecase.BreakerActionUseCase
+	logger               logger.Logger
+}
+
+func NewUpstreamHandler(
+	rateLimitsUseCase *usecase.ListRateLimitsUseCase,
+	breakersUseCase *usecase.ListBreakersUseCase,
+	breakerActionUseCase *usecase.BreakerActionUseCase,
+	logger logger.Logger,
+) *UpstreamHandler {
+	return &UpstreamHandler{
+		rateLimitsUseCase:    rateLimitsUseCase,
+		breakersUseCase:      breakersUseCase,
+		breakerActionUseCase: breakerActionUseCase,
+		logger:               logger,
+	}
+}
+
+// GetRateLimits godoc
+// @Summary      List outbound rate limiters
+// @Description  Show the token bucket of every external API, including back-off requested through Retry-After and the quota last reported in X-RateLimit-* headers
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} dto.GetRateLimitsResponse "Rate limiter states ordered by name"
+// @Failure      401 {object} dto.ErrorResponse "Missing or invalid admin token"
+// @Failure      500 {object} dto.ErrorResponse "Internal server error"
+// @Router       /admin/rate-limits [get]
+func (h *UpstreamHandler) GetRateLimits(c echo.Context) error {
+	response, err := h.rateLimitsUseCase.Execute(c.Request().Context())
+	if err != nil {
+		h.logger.Error("List rate limits failed", "error", err.Error())
+		return ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.GetRateLimitsResponse{
+		Limiters: response.Limiters,
+	})
+}
+
+// GetBreakers godoc
+// @Summary      List circuit breakers
+// @Description  Show the circuit breaker of every external API that has been called or forced open: state, failure count, last failure and next retry time
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} dto.GetBreakersResponse "Circuit breakers ordered by name"
+// @Failure      401 {object} dto.ErrorResponse "Missing or invalid admin token"
+// @Failure      500 {object} dto.ErrorResponse "Internal server error"
+// @Router       /admin/breakers [get]
+func (h *UpstreamHandler) GetBreakers(c echo.Context) error {
+	response, err := h.breakersUseCase.Execute(c.Request().Context())
+	if err != nil {
+		h.logger.Error("List circuit breakers failed", "error", err.Error())
+		return ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.GetBreakersResponse{
+		Breakers: response.Breakers,
+	})
+}
+
+// ApplyBreakerAction godoc
+// @Summary      Override a circuit breaker
+// @Description  force-open rejects every call to the upstream and force-close lets every call through until the breaker is reset; reset returns it to automatic operation with no recorded failures. Breakers are kept per replica, the override only applies to the replica that serves the request. A breaker can be forced open before its first call.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        name path string true "Breaker name" example(pokemon-api)
+// @Param        action path string true "Action" Enums(force-open, force-close, reset)
+// @Success      200 {object} dto.BreakerActionResponse "Breaker state after the action"
+// @Failure      400 {object} dto.ErrorResponse "Unknown action"
+// @Failure      401 {object} dto.ErrorResponse "Missing or invalid admin token"
+// @Failure      404 {object} dto.ErrorResponse "Breaker not found, on force-close and reset"
+// @Failure      500 {object} dto.ErrorResponse "Internal server error"
+// @Router       /admin/breakers/{name}/{action} [post]
+func (h *UpstreamHandler) ApplyBreakerAction(c echo.Context) error {
+	request := usecase.BreakerActionRequest{
+		Name:   c.Param("name"),
+		Action: c.Param("action"),
+	}
+
+	response, err := h.breakerActionUseCase.Execute(c.Request().Context(), request)
+	if err != nil {
+		h.logger.Error("Circuit breaker action failed", "error", err.Error(), "name", request.Name, "action", request.Action)
+		return ErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.BreakerActionResponse{
+		Breaker: response.Breaker,
+	})
+}
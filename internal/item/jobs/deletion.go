@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/item/entity"
+)
+
+// DeletionPolicyFor resolves the deletion policy configured for a source
+func DeletionPolicyFor(config config.WorkerConfig, source string) (entity.DeletionPolicy, error) {
+	value, ok := config.DeletionPolicy[source]
+	if !ok {
+		value = config.DefaultDeletionPolicy
+	}
+
+	policy, err := entity.ParseDeletionPolicy(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid deletion policy for %s: %w", source, err)
+	}
+	return policy, nil
+}
@@ -5,17 +5,21 @@ import (
 	"time"
 
 	"github.com/zainokta/item-sync/internal/item/entity"
-	"github.com/zainokta/item-sync/pkg/api"
 )
 
 type JobRepository interface {
 	CreateSyncJobRecord(ctx context.Context, name string, apiType string) (int64, error)
-	UpdateSyncJobRecord(ctx context.Context, jobID int64, status string, processed, succeeded, failed int, lastErr error, executionTime time.Duration) error
+	UpdateSyncJobRecord(ctx context.Context, jobID int64, status string, stats entity.SyncJobStats, lastErr error, executionTime time.Duration) error
+	FindSyncCheckpoint(ctx context.Context, apiSource string) (*entity.SyncCheckpoint, error)
+	SaveSyncCheckpoint(ctx context.Context, checkpoint entity.SyncCheckpoint) error
+	DeleteSyncCheckpoint(ctx context.Context, apiSource string) error
 }
 
 // ItemSaver interface for saving items
 type ItemSaver interface {
-	UpsertWithHash(ctx context.Context, apiSource string, externalItem entity.ExternalItem) error
+	FindByAPISource(ctx context.Context, apiSource string, limit, offset int) ([]entity.Item, error)
+	UpsertManyWithHash(ctx context.Context, apiSource string, externalItems []entity.ExternalItem) ([]entity.UpsertResult, error)
+	ReconcileMissingItems(ctx context.Context, apiSource string, seenIDs []int, policy entity.DeletionPolicy) (int, error)
 }
 
 // ItemCache interface for caching
@@ -26,11 +30,3 @@ type ItemCache interface {
 	SetItem(ctx context.Context, key string, item entity.Item, ttl time.Duration) error
 	Invalidate(ctx context.Context, key string) error
 }
-
-// ExternalAPIClient interface for external API calls
-type ExternalAPIClient interface {
-	Fetch(ctx context.Context, apiName string, operation string, params map[string]interface{}) ([]entity.ExternalItem, error)
-	FetchByID(ctx context.Context, apiName string, id int) (entity.ExternalItem, error)
-	FetchPaginated(ctx context.Context, apiName string, operation string, params map[string]interface{}) (*api.PaginatedResponse, error)
-}
-
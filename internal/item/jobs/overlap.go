@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/item/entity"
+)
+
+// OverlapPolicyFor resolves the overlap policy configured for a source
+func OverlapPolicyFor(config config.WorkerConfig, source string) (entity.OverlapPolicy, error) {
+	value, ok := config.OverlapPolicy[source]
+	if !ok {
+		value = config.DefaultOverlapPolicy
+	}
+
+	policy, err := entity.ParseOverlapPolicy(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid overlap policy for %s: %w", source, err)
+	}
+	return policy, nil
+}
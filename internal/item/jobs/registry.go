@@ -0,0 +1,176 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/zainokta/item-sync/internal/item/entity"
+)
+
+// ErrSyncJobCancelled is the cancellation cause used when a running job is cancelled on request
+var ErrSyncJobCancelled = errors.New("sync job cancelled")
+
+// JobConflictError is returned when the overlap policy rejects a run because its source is already syncing
+type JobConflictError struct {
+	APISource    string
+	RunningJobID int64
+}
+
+func (e *JobConflictError) Error() string {
+	return fmt.Sprintf("sync job %d is already running for %s", e.RunningJobID, e.APISource)
+}
+
+type runningJob struct {
+	jobID  int64
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// JobRegistry keeps the cancel func of every sync job running in this process
+// and allows only one active job per API source
+type JobRegistry struct {
+	mu      sync.Mutex
+	running map[int64]*runningJob
+	active  map[string]*runningJob
+}
+
+func NewJobRegistry() *JobRegistry {
+	return &JobRegistry{
+		running: make(map[int64]*runningJob),
+		active:  make(map[string]*runningJob),
+	}
+}
+
+// Claim registers a run of the source without waiting, so that callers can reject a conflicting run before
+// creating its record. Under skip a busy source is a *JobConflictError, under cancel-previous the active job is
+// cancelled right away and under queue the claim waits its turn in Wait. The claim must be released once the run
+// has finished or when it never starts.
+func (r *JobRegistry) Claim(ctx context.Context, source string, policy entity.OverlapPolicy) (*Claim, error) {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	claim := &Claim{
+		registry: r,
+		source:   source,
+		policy:   policy,
+		ctx:      jobCtx,
+		job: &runningJob{
+			cancel: cancel,
+			done:   make(chan struct{}),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, busy := r.active[source]
+	if !busy {
+		r.active[source] = claim.job
+		return claim, nil
+	}
+
+	switch policy {
+	case entity.OverlapQueue:
+	case entity.OverlapCancelPrevious:
+		current.cancel(entity.ErrJobSuperseded)
+	default:
+		cancel(nil)
+		return nil, &JobConflictError{APISource: source, RunningJobID: current.jobID}
+	}
+	return claim, nil
+}
+
+// Claim is a run registered with a JobRegistry, see JobRegistry.Claim
+type Claim struct {
+	registry *JobRegistry
+	source   string
+	policy   entity.OverlapPolicy
+	ctx      context.Context
+	job      *runningJob
+	release  sync.Once
+}
+
+// Bind ties the claim to the record of the run, from then on the run can be cancelled by its ID
+func (c *Claim) Bind(jobID int64) {
+	c.registry.mu.Lock()
+	defer c.registry.mu.Unlock()
+
+	c.job.jobID = jobID
+	c.registry.running[jobID] = c.job
+}
+
+// Wait blocks until the run is the active job of its source and returns the context of the run.
+// The claim is released when the run is cancelled while it waits.
+func (c *Claim) Wait() (context.Context, error) {
+	r := c.registry
+	for {
+		r.mu.Lock()
+		current, busy := r.active[c.source]
+		if !busy || current == c.job {
+			r.active[c.source] = c.job
+			r.mu.Unlock()
+			return c.ctx, nil
+		}
+		r.mu.Unlock()
+
+		switch c.policy {
+		case entity.OverlapQueue:
+		case entity.OverlapCancelPrevious:
+			current.cancel(entity.ErrJobSuperseded)
+		default:
+			c.Release()
+			return nil, &JobConflictError{APISource: c.source, RunningJobID: current.jobID}
+		}
+
+		select {
+		case <-current.done:
+		case <-c.ctx.Done():
+			err := context.Cause(c.ctx)
+			c.Release()
+			return nil, err
+		}
+	}
+}
+
+// Release frees the source for the next run, it may be called more than once
+func (c *Claim) Release() {
+	c.release.Do(func() {
+		r := c.registry
+		r.mu.Lock()
+		if r.running[c.job.jobID] == c.job {
+			delete(r.running, c.job.jobID)
+		}
+		if r.active[c.source] == c.job {
+			delete(r.active, c.source)
+			close(c.job.done)
+		}
+		r.mu.Unlock()
+		c.job.cancel(nil)
+	})
+}
+
+// ActiveJob returns the ID of the job currently syncing the source
+func (r *JobRegistry) ActiveJob(source string) (int64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.active[source]
+	if !ok {
+		return 0, false
+	}
+	return job.jobID, true
+}
+
+// Cancel stops a running or queued job and reports whether the job was found
+func (r *JobRegistry) Cancel(jobID int64) bool {
+	r.mu.Lock()
+	job, ok := r.running[jobID]
+	r.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	job.cancel(ErrSyncJobCancelled)
+	return true
+}
@@ -0,0 +1,150 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/internal/item/entity"
+)
+
+func TestJobRegistry_Claim_Skip(t *testing.T) {
+	registry := NewJobRegistry()
+
+	claim, err := registry.Claim(context.Background(), "pokemon", entity.OverlapSkip)
+	require.NoError(t, err)
+	claim.Bind(1)
+
+	_, err = registry.Claim(context.Background(), "pokemon", entity.OverlapSkip)
+	var conflict *JobConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, int64(1), conflict.RunningJobID)
+	runningJobID, _ := registry.ActiveJob("pokemon")
+	assert.Equal(t, int64(1), runningJobID, "A rejected claim should not replace the active job")
+
+	// Other sources are independent
+	weather, err := registry.Claim(context.Background(), "openweather", entity.OverlapSkip)
+	require.NoError(t, err)
+	weather.Release()
+
+	claim.Release()
+	claim, err = registry.Claim(context.Background(), "pokemon", entity.OverlapSkip)
+	require.NoError(t, err)
+	claim.Release()
+}
+
+func TestJobRegistry_Claim_Queue(t *testing.T) {
+	registry := NewJobRegistry()
+
+	claim, err := registry.Claim(context.Background(), "pokemon", entity.OverlapQueue)
+	require.NoError(t, err)
+	claim.Bind(1)
+
+	queued, err := registry.Claim(context.Background(), "pokemon", entity.OverlapQueue)
+	require.NoError(t, err, "A queued claim should be accepted right away")
+	queued.Bind(2)
+
+	started := make(chan struct{})
+	go func() {
+		_, err := queued.Wait()
+		assert.NoError(t, err)
+		close(started)
+		queued.Release()
+	}()
+
+	select {
+	case <-started:
+		t.Fatal("Queued job should wait for the running job")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	claim.Release()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("Queued job should start once the running job is released")
+	}
+}
+
+func TestJobRegistry_Claim_QueuedJobCanBeCancelled(t *testing.T) {
+	registry := NewJobRegistry()
+
+	claim, err := registry.Claim(context.Background(), "pokemon", entity.OverlapQueue)
+	require.NoError(t, err)
+	claim.Bind(1)
+	defer claim.Release()
+
+	queued, err := registry.Claim(context.Background(), "pokemon", entity.OverlapQueue)
+	require.NoError(t, err)
+	queued.Bind(2)
+
+	result := make(chan error)
+	go func() {
+		_, err := queued.Wait()
+		result <- err
+	}()
+
+	assert.True(t, registry.Cancel(2), "A bound run should be cancellable while it waits")
+	assert.ErrorIs(t, <-result, ErrSyncJobCancelled)
+	assert.False(t, registry.Cancel(2), "A cancelled queued run should be released")
+}
+
+func TestJobRegistry_Claim_CancelPrevious(t *testing.T) {
+	registry := NewJobRegistry()
+
+	previous, err := registry.Claim(context.Background(), "pokemon", entity.OverlapCancelPrevious)
+	require.NoError(t, err)
+	previous.Bind(1)
+	previousCtx, err := previous.Wait()
+	require.NoError(t, err)
+
+	// The previous job releases the source once it notices the cancellation
+	go func() {
+		<-previousCtx.Done()
+		previous.Release()
+	}()
+
+	next, err := registry.Claim(context.Background(), "pokemon", entity.OverlapCancelPrevious)
+	require.NoError(t, err)
+	next.Bind(2)
+	_, err = next.Wait()
+	require.NoError(t, err)
+	defer next.Release()
+
+	assert.ErrorIs(t, context.Cause(previousCtx), entity.ErrJobSuperseded)
+	runningJobID, ok := registry.ActiveJob("pokemon")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), runningJobID)
+}
+
+func TestJobRegistry_Claim(t *testing.T) {
+	registry := NewJobRegistry()
+
+	// The source is taken as soon as it is claimed, before the run has a record
+	claim, err := registry.Claim(context.Background(), "pokemon", entity.OverlapSkip)
+	require.NoError(t, err)
+
+	_, err = registry.Claim(context.Background(), "pokemon", entity.OverlapSkip)
+	var conflict *JobConflictError
+	require.ErrorAs(t, err, &conflict)
+
+	claim.Bind(1)
+	runningJobID, ok := registry.ActiveJob("pokemon")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), runningJobID)
+
+	jobCtx, err := claim.Wait()
+	require.NoError(t, err)
+	assert.True(t, registry.Cancel(1))
+	assert.ErrorIs(t, context.Cause(jobCtx), ErrSyncJobCancelled)
+
+	claim.Release()
+	claim.Release()
+	assert.False(t, registry.Cancel(1), "A released run should no longer be tracked")
+
+	claim, err = registry.Claim(context.Background(), "pokemon", entity.OverlapSkip)
+	require.NoError(t, err)
+	claim.Release()
+}
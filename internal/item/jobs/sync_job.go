@@ -2,33 +2,51 @@ package jobs
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/zainokta/item-sync/config"
 	"github.com/zainokta/item-sync/internal/item/entity"
 	"github.com/zainokta/item-sync/internal/item/strategy"
+	"github.com/zainokta/item-sync/pkg/api"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/metrics"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type SyncJob struct {
 	name           string
 	itemRepository ItemSaver
 	jobRepository  JobRepository
-	apiClient      ExternalAPIClient
+	itemCache      ItemCache
+	registry       *JobRegistry
+	syncStrategy   strategy.SyncStrategy
 	apiType        string
+	operation      string
 	logger         logger.Logger
 	config         config.Config
 	params         map[string]interface{}
+	overlapPolicy  entity.OverlapPolicy
+
+	// narrowed is set when the caller changed the provider default params, the listing then only covers part of
+	// the source
+	narrowed bool
 }
 
 func NewSyncJob(
 	name string,
 	itemRepository ItemSaver,
 	jobRepository JobRepository,
-	apiClient ExternalAPIClient,
+	itemCache ItemCache,
+	registry *JobRegistry,
+	syncStrategy strategy.SyncStrategy,
 	apiType string,
+	operation string,
 	logger logger.Logger,
 	config config.Config,
 	params map[string]interface{},
@@ -38,25 +56,53 @@ func NewSyncJob(
 		params = make(map[string]interface{})
 	}
 
+	overlapPolicy, err := OverlapPolicyFor(config.Worker, apiType)
+	if err != nil {
+		logger.Warn("Falling back to skip overlap policy", "api_type", apiType, "error", err)
+		overlapPolicy = entity.OverlapSkip
+	}
+
 	return &SyncJob{
 		name:           name,
 		itemRepository: itemRepository,
 		jobRepository:  jobRepository,
-		apiClient:      apiClient,
+		itemCache:      itemCache,
+		registry:       registry,
+		syncStrategy:   syncStrategy,
 		apiType:        apiType,
+		operation:      operation,
 		logger:         logger,
 		config:         config,
 		params:         params,
+		overlapPolicy:  overlapPolicy,
 	}
 }
 
+// WithNarrowedParams marks the job params as narrowed by the caller, see provider.Provider.NarrowsListing. Runs of
+// a narrowed job never reconcile the items missing from their listing.
+func (j *SyncJob) WithNarrowedParams(narrowed bool) *SyncJob {
+	j.narrowed = narrowed
+	return j
+}
+
 func (j *SyncJob) Name() string {
 	return j.name
 }
 
 func (j *SyncJob) Execute(ctx context.Context) error {
-	if j.apiClient == nil {
-		return fmt.Errorf("API client not configured for %s", j.apiType)
+	if j.syncStrategy == nil {
+		return fmt.Errorf("sync strategy not configured for %s", j.apiType)
+	}
+
+	ctx, span := tracing.Start(ctx, "SyncJob.Execute",
+		attribute.String("job", j.name),
+		attribute.String("api_source", j.apiType))
+	defer span.End()
+
+	claim, err := j.Claim(ctx)
+	if err != nil {
+		j.logger.Info("Skipping background sync job", "api_type", j.apiType, "reason", err)
+		return err
 	}
 
 	j.logger.Info("Starting background sync job", "api_type", j.apiType)
@@ -64,155 +110,454 @@ func (j *SyncJob) Execute(ctx context.Context) error {
 	jobID, err := j.jobRepository.CreateSyncJobRecord(ctx, j.name, j.apiType)
 	if err != nil {
 		j.logger.Error("Failed to create sync job record", "error", err)
+		if claim != nil {
+			claim.Release()
+		}
 		return err
 	}
 
+	return j.Run(ctx, jobID, claim)
+}
+
+// Run executes the sync against an already created sync_jobs record. claim is the claim of the source made with
+// Claim before the record was created, Run claims the source itself when it is nil.
+func (j *SyncJob) Run(ctx context.Context, jobID int64, claim *Claim) error {
+	if j.syncStrategy == nil {
+		return fmt.Errorf("sync strategy not configured for %s", j.apiType)
+	}
+
+	ctx, span := tracing.Start(ctx, "SyncJob.Run",
+		attribute.String("job", j.name),
+		attribute.String("api_source", j.apiType),
+		attribute.Int64("sync_job.id", jobID))
+
 	startTime := time.Now()
-	itemsProcessed := 0
-	itemsSucceeded := 0
-	itemsFailed := 0
+	var stats entity.SyncJobStats
 	var lastError error
 
 	defer func() {
 		executionTime := time.Since(startTime)
-		status := "completed"
-		if lastError != nil {
-			status = "failed"
+		status := entity.SyncJobStatusCompleted
+		if cause := context.Cause(ctx); isCancellation(cause) {
+			lastError = cause
+		}
+		if isCancellation(lastError) {
+			status = entity.SyncJobStatusCancelled
+		} else if lastError != nil {
+			status = entity.SyncJobStatusFailed
 		}
 
-		err := j.jobRepository.UpdateSyncJobRecord(ctx, jobID, status, itemsProcessed, itemsSucceeded, itemsFailed, lastError, executionTime)
+		// The job context may already be done, the final record update must still go through
+		err := j.jobRepository.UpdateSyncJobRecord(context.WithoutCancel(ctx), jobID, status, stats, lastError, executionTime)
 		if err != nil {
 			j.logger.Error("Failed to update sync job record", "error", err)
 		}
+		j.observe(status, stats, executionTime)
+
+		span.SetAttributes(
+			attribute.String("sync_job.status", status),
+			attribute.Int("items.processed", stats.Processed),
+			attribute.Int("items.failed", stats.Failed),
+			attribute.Int("pages.not_modified", stats.PagesNotModified))
+		tracing.End(span, lastError)
 	}()
 
-	switch j.apiType {
-	case "pokemon":
-		itemsProcessed, itemsSucceeded, itemsFailed, lastError = j.syncPokemonData(ctx)
-	case "openweather":
-		itemsProcessed, itemsSucceeded, itemsFailed, lastError = j.syncWeatherData(ctx)
-	default:
-		lastError = fmt.Errorf("unsupported API type: %s", j.apiType)
-		return lastError
+	if claim == nil && j.registry != nil {
+		var err error
+		if claim, err = j.Claim(ctx); err != nil {
+			j.logger.Warn("Sync job did not start", "job_id", jobID, "api_type", j.apiType, "error", err)
+			lastError = err
+			return err
+		}
+	}
+	if claim != nil {
+		defer claim.Release()
+		claim.Bind(jobID)
+
+		jobCtx, err := claim.Wait()
+		if err != nil {
+			j.logger.Warn("Sync job did not start", "job_id", jobID, "api_type", j.apiType, "error", err)
+			lastError = err
+			return err
+		}
+		// The claim context was derived before this span was started
+		ctx = trace.ContextWithSpan(jobCtx, span)
 	}
 
+	// Item versions written by this run reference its record
+	ctx = entity.WithSyncJobID(ctx, jobID)
+
+	stats, lastError = j.syncItems(ctx)
+
 	if lastError != nil {
 		j.logger.Error("Sync job completed with errors",
-			"processed", itemsProcessed,
-			"succeeded", itemsSucceeded,
-			"failed", itemsFailed,
+			"processed", stats.Processed,
+			"succeeded", stats.Succeeded,
+			"failed", stats.Failed,
 			"error", lastError)
 		return lastError
 	}
 
 	j.logger.Info("Sync job completed successfully",
-		"processed", itemsProcessed,
-		"succeeded", itemsSucceeded,
-		"failed", itemsFailed)
+		"processed", stats.Processed,
+		"succeeded", stats.Succeeded,
+		"failed", stats.Failed,
+		"inserted", stats.Inserted,
+		"updated", stats.Updated,
+		"unchanged", stats.Unchanged,
+		"pages_not_modified", stats.PagesNotModified)
 
 	return nil
 }
 
-func (j *SyncJob) syncPokemonData(ctx context.Context) (processed, succeeded, failed int, lastErr error) {
-	pokemonStrategy := strategy.NewPokemonSyncStrategy(j.logger, j.apiClient)
+// observe records a finished run in the sync job metrics
+func (j *SyncJob) observe(status string, stats entity.SyncJobStats, executionTime time.Duration) {
+	metrics.SyncJobRuns.WithLabelValues(j.apiType, status).Inc()
+	metrics.SyncJobDuration.WithLabelValues(j.apiType, status).Observe(executionTime.Seconds())
 
-	request := strategy.SyncItemsRequest{
-		APISource: "pokemon",
-		Operation: "list",
-		Params:    j.params,
+	outcomes := map[string]int{
+		"inserted":  stats.Inserted,
+		"updated":   stats.Updated,
+		"unchanged": stats.Unchanged,
+		"failed":    stats.Failed,
+	}
+	for outcome, count := range outcomes {
+		metrics.SyncJobItems.WithLabelValues(j.apiType, outcome).Add(float64(count))
 	}
+}
 
-	var items []entity.ExternalItem
-	var err error
+// Claim reserves the source for a run of this job according to its overlap policy, see JobRegistry.Claim.
+// A conflicting run is reported as *JobConflictError before its record is created. The claim is nil when the
+// job has no registry.
+func (j *SyncJob) Claim(ctx context.Context) (*Claim, error) {
+	if j.registry == nil {
+		return nil, nil
+	}
+	return j.registry.Claim(ctx, j.apiType, j.overlapPolicy)
+}
 
-	// Check if user wants limited fetch or full sync
-	if _, hasLimit := j.params["limit"]; hasLimit {
-		j.logger.Info("Fetching limited Pokemon data", "params", j.params)
-		items, err = pokemonStrategy.Fetch(ctx, request)
-	} else {
-		j.logger.Info("Fetching all Pokemon data")
-		items, err = pokemonStrategy.FetchAllItems(ctx, request)
+// loadCheckpoint returns the checkpoint the next full pass starts from and whether it resumes an unfinished pass.
+// The source checkpoint belongs to passes over the default params, narrowed runs always start from the beginning.
+func (j *SyncJob) loadCheckpoint(ctx context.Context) (*entity.SyncCheckpoint, bool) {
+	if j.narrowed {
+		return &entity.SyncCheckpoint{APISource: j.apiType}, false
+	}
+
+	checkpoint, err := j.jobRepository.FindSyncCheckpoint(ctx, j.apiType)
+	if err != nil {
+		j.logger.Warn("Failed to load sync checkpoint, starting from the beginning", "api_type", j.apiType, "error", err)
+	}
+	if checkpoint == nil || checkpoint.IsZero() {
+		return &entity.SyncCheckpoint{APISource: j.apiType}, false
 	}
 
+	j.logger.Info("Resuming sync from checkpoint",
+		"api_type", j.apiType,
+		"offset", checkpoint.Offset,
+		"next_url", checkpoint.NextURL,
+		"cursor", checkpoint.Cursor,
+		"watermark", checkpoint.Watermark,
+		"checkpoint_job_id", checkpoint.SyncJobID)
+	return checkpoint, true
+}
+
+// hasStoredItems reports whether the source has any item stored, a failed lookup counts as stored
+func (j *SyncJob) hasStoredItems(ctx context.Context) bool {
+	items, err := j.itemRepository.FindByAPISource(ctx, j.apiType, 1, 0)
 	if err != nil {
-		j.logger.Error("Failed to fetch Pokemon data using strategy", "error", err)
-		lastErr = err
+		j.logger.Warn("Failed to look up stored items", "api_type", j.apiType, "error", err)
+		return true
+	}
+	return len(items) > 0
+}
+
+// saveCheckpoint stores how far the current pass got, the position of a narrowed run is not kept
+func (j *SyncJob) saveCheckpoint(ctx context.Context, checkpoint *entity.SyncCheckpoint) {
+	if j.narrowed || checkpoint == nil || checkpoint.IsZero() {
 		return
 	}
 
-	j.logger.Info("Fetched Pokemon data successfully", "total_items", len(items))
+	// The job context may be done when the pass was interrupted, the checkpoint must still be written
+	ctx = context.WithoutCancel(ctx)
 
-	for _, item := range items {
-		processed++
+	if jobID, ok := entity.SyncJobIDFromContext(ctx); ok {
+		checkpoint.SyncJobID = jobID
+	}
+	if err := j.jobRepository.SaveSyncCheckpoint(ctx, *checkpoint); err != nil {
+		j.logger.Error("Failed to save sync checkpoint", "api_type", j.apiType, "error", err)
+		return
+	}
+	j.logger.Debug("Saved sync checkpoint", "api_type", j.apiType, "offset", checkpoint.Offset, "cursor", checkpoint.Cursor)
+}
 
-		err := j.itemRepository.UpsertWithHash(ctx, "pokemon", item)
-		if err != nil {
-			j.logger.Error("Failed to store Pokemon item", "id", item.ID, "error", err)
-			failed++
-			lastErr = err
-		} else {
-			succeeded++
-			j.logger.Debug("Successfully stored Pokemon item", "id", item.ID, "title", item.Title)
-		}
-
-		if processed%100 == 0 {
-			select {
-			case <-ctx.Done():
-				lastErr = ctx.Err()
-				return
-			default:
-			}
+// clearCheckpoint makes the next pass start from the beginning, a narrowed run leaves the checkpoint of the
+// default passes alone
+func (j *SyncJob) clearCheckpoint(ctx context.Context) {
+	if j.narrowed {
+		return
+	}
+	if err := j.jobRepository.DeleteSyncCheckpoint(context.WithoutCancel(ctx), j.apiType); err != nil {
+		j.logger.Error("Failed to clear sync checkpoint", "api_type", j.apiType, "error", err)
+	}
+}
+
+func (j *SyncJob) reconcileMissingItems(ctx context.Context, seenIDs []int) error {
+	policy, err := DeletionPolicyFor(j.config.Worker, j.apiType)
+	if err != nil {
+		return err
+	}
+
+	affected, err := j.itemRepository.ReconcileMissingItems(ctx, j.apiType, seenIDs, policy)
+	if err != nil {
+		j.logger.Error("Failed to handle items missing upstream", "api_type", j.apiType, "policy", policy, "error", err)
+		return err
+	}
+
+	if affected > 0 {
+		j.logger.Info("Handled items missing upstream", "api_type", j.apiType, "policy", policy, "items", affected)
+		j.invalidateListings(ctx)
+	}
+	return nil
+}
+
+// invalidateListings drops the cached item listings of the source, they may still hold items that were just
+// flagged or deleted
+func (j *SyncJob) invalidateListings(ctx context.Context) {
+	if j.itemCache == nil {
+		return
+	}
+	for _, pattern := range entity.ItemListCachePatterns(j.apiType) {
+		if err := j.itemCache.Invalidate(ctx, pattern); err != nil {
+			j.logger.Warn("Failed to invalidate cached item listings", "api_type", j.apiType, "pattern", pattern, "error", err)
 		}
 	}
+}
+
+// errListingChanged stops a resumed pass that found a page modified after the checkpoint watermark
+var errListingChanged = errors.New("listing changed since the checkpoint was saved")
+
+func isCancellation(err error) bool {
+	return errors.Is(err, ErrSyncJobCancelled) || errors.Is(err, entity.ErrJobSuperseded) || errors.Is(err, entity.ErrLeaseLost)
+}
+
+func (j *SyncJob) syncItems(ctx context.Context) (stats entity.SyncJobStats, lastErr error) {
+	request := strategy.SyncItemsRequest{
+		APISource: j.apiType,
+		Operation: j.operation,
+		Params:    j.params,
+	}
+
+	// Check if user wants limited fetch or full sync
+	if _, hasLimit := j.params["limit"]; hasLimit {
+		j.logger.Info("Fetching limited data", "api_type", j.apiType, "params", j.params)
+		items, err := j.syncStrategy.Fetch(ctx, request)
+		stats, _, lastErr = j.storeFetchedItems(ctx, items, err)
+	} else {
+		stats, lastErr = j.syncAllItems(ctx, request)
+	}
 
-	j.logger.Info("Pokemon data sync completed", "processed", processed, "succeeded", succeeded, "failed", failed)
+	j.logger.Info("Data sync completed", "api_type", j.apiType, "processed", stats.Processed, "succeeded", stats.Succeeded, "failed", stats.Failed)
 	return
 }
 
-func (j *SyncJob) syncWeatherData(ctx context.Context) (processed, succeeded, failed int, lastErr error) {
-	// Get cities from params or use defaults
-	cities := []string{"Jakarta", "Bandung", "Surabaya"}
-	if citiesParam, ok := j.params["cities"].(string); ok && len(citiesParam) > 0 {
-		cities = strings.Split(citiesParam, ",")
+// syncAllItems runs a full pass from the source checkpoint. Streaming strategies have every page stored and
+// checkpointed as it arrives, other strategies have their items stored once the whole listing is fetched.
+func (j *SyncJob) syncAllItems(ctx context.Context, request strategy.SyncItemsRequest) (stats entity.SyncJobStats, lastErr error) {
+	var resumed bool
+	request.Checkpoint, resumed = j.loadCheckpoint(ctx)
+	j.logger.Info("Fetching all data", "api_type", j.apiType, "resumed", resumed)
+
+	// Cached page validators outlive the stored items, for example when the database was reset
+	if !j.hasStoredItems(ctx) {
+		j.logger.Info("No items stored yet, fetching every page in full", "api_type", j.apiType)
+		ctx = api.WithoutConditionalRequests(ctx)
 	}
 
-	for _, city := range cities {
-		// Merge job params with city-specific params
-		params := make(map[string]interface{})
-		for k, v := range j.params {
-			params[k] = v
-		}
-		params["city"] = city
+	var seenIDs []int
+	var fetchErr error
+	// Set once a page could not be stored completely, the checkpoint must not move past it
+	held := false
+	// Set once the strategy reached the end of the listing
+	complete := false
+
+	if streaming, ok := j.syncStrategy.(strategy.StreamingSyncStrategy); ok {
+		// A page modified after the watermark of the interrupted pass may have moved items across the saved position
+		resumedFrom := *request.Checkpoint
+		handle := func(ctx context.Context, page strategy.Page) error {
+			if resumed && resumedFrom.ModifiedSince(page.LastModified) {
+				return errListingChanged
+			}
+			complete = complete || page.Last
+			for _, item := range page.Items {
+				seenIDs = append(seenIDs, item.ID)
+			}
 
-		items, err := j.apiClient.Fetch(ctx, "openweather", "weather", params)
-		if err != nil {
-			j.logger.Error("Failed to fetch weather data", "error", err, "city", city)
-			failed++
-			lastErr = err
-			continue
-		}
+			// Its items were stored when the page last changed
+			if page.NotModified {
+				stats.PagesNotModified++
+				if !held {
+					j.saveCheckpoint(ctx, request.Checkpoint)
+				}
+				j.logger.Debug("Skipped page not modified upstream", "api_type", j.apiType, "items", len(page.Items))
+				return ctx.Err()
+			}
 
-		for _, item := range items {
-			processed++
+			pageStats, err := j.storeItems(ctx, page.Items)
+			stats.Merge(pageStats)
 
-			err := j.itemRepository.UpsertWithHash(ctx, "openweather", item)
+			if err != nil || pageStats.Failed > 0 {
+				held = true
+			}
 			if err != nil {
-				j.logger.Error("Failed to store weather item", "id", item.ID, "error", err)
-				failed++
 				lastErr = err
-			} else {
-				succeeded++
-				j.logger.Debug("Successfully stored weather item", "id", item.ID, "title", item.Title)
 			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+
+			// A page is only answered with 304 on a later pass once all of its items are stored
+			if err == nil && pageStats.Failed == 0 {
+				if err := page.Validators.Commit(ctx); err != nil {
+					j.logger.Warn("Failed to keep page validators", "api_type", j.apiType, "error", err)
+				}
+				request.Checkpoint.AdvanceWatermark(page.LastModified)
+			}
+
+			if !held {
+				j.saveCheckpoint(ctx, request.Checkpoint)
+			}
+			j.logger.Debug("Stored page", "api_type", j.apiType, "items", len(page.Items), "processed", stats.Processed)
+			return nil
+		}
+
+		fetchErr = streaming.StreamAllItems(ctx, request, handle)
+		if errors.Is(fetchErr, errListingChanged) {
+			// Unchanged pages answer 304 when conditional requests are on, so starting over is cheap
+			j.logger.Info("Listing changed since the checkpoint was saved, starting over", "api_type", j.apiType, "watermark", resumedFrom.Watermark)
+			request.Checkpoint = &entity.SyncCheckpoint{APISource: j.apiType}
+			resumed, held, complete, seenIDs = false, false, false, nil
+			fetchErr = streaming.StreamAllItems(ctx, request, handle)
 		}
 
+		if fetchErr != nil {
+			j.logger.Error("Failed to fetch data using strategy", "api_type", j.apiType, "processed", stats.Processed, "error", fetchErr)
+			if lastErr == nil {
+				lastErr = fetchErr
+			}
+		}
+	} else {
+		var items []entity.ExternalItem
+		items, fetchErr = j.syncStrategy.FetchAllItems(ctx, request)
+		complete = fetchErr == nil
+
+		var storeErr error
+		stats, storeErr, lastErr = j.storeFetchedItems(ctx, items, fetchErr)
+		for _, item := range items {
+			seenIDs = append(seenIDs, item.ID)
+		}
+
+		held = storeErr != nil || stats.Failed > 0
+		if !held && fetchErr != nil {
+			j.saveCheckpoint(ctx, request.Checkpoint)
+		}
+	}
+
+	if fetchErr == nil && !held {
+		j.clearCheckpoint(ctx)
+	}
+
+	// Only a complete listing of the default params from the first page tells which stored items are gone upstream
+	if !resumed && complete && !j.narrowed && fetchErr == nil && ctx.Err() == nil && len(seenIDs) > 0 {
+		if reconcileErr := j.reconcileMissingItems(ctx, seenIDs); reconcileErr != nil {
+			lastErr = reconcileErr
+		}
+	}
+
+	return
+}
+
+// storeFetchedItems stores what a fetch returned, strategies may return the items they managed to fetch along with the error
+func (j *SyncJob) storeFetchedItems(ctx context.Context, items []entity.ExternalItem, fetchErr error) (stats entity.SyncJobStats, storeErr, lastErr error) {
+	if fetchErr != nil {
+		j.logger.Error("Failed to fetch data using strategy", "api_type", j.apiType, "fetched_items", len(items), "error", fetchErr)
+		lastErr = fetchErr
+		if len(items) == 0 {
+			return
+		}
+	} else {
+		j.logger.Info("Fetched data successfully", "api_type", j.apiType, "total_items", len(items))
+	}
+
+	stats, storeErr = j.storeItems(ctx, items)
+	if storeErr != nil {
+		lastErr = storeErr
+	}
+	return
+}
+
+// storeItems upserts the items in batches of Database.UpsertBatchSize through a pool of at most
+// Worker.MaxWorkers goroutines. Batches not yet handed to a worker are dropped once the context is done.
+// Incomplete items are counted as failed without being stored.
+func (j *SyncJob) storeItems(ctx context.Context, items []entity.ExternalItem) (stats entity.SyncJobStats, lastErr error) {
+	ctx, span := tracing.Start(ctx, "SyncJob.storeItems", attribute.Int("items", len(items)))
+	defer func() { tracing.End(span, lastErr) }()
+
+	items = slices.DeleteFunc(slices.Clone(items), func(item entity.ExternalItem) bool {
+		if item.Incomplete {
+			j.logger.Warn("Skipping incomplete item, the stored version is kept", "api_type", j.apiType, "id", item.ID)
+			stats.Add(entity.UpsertResult{ExternalID: item.ID, Outcome: entity.UpsertFailed})
+		}
+		return item.Incomplete
+	})
+
+	batches := slices.Collect(slices.Chunk(items, max(j.config.Database.UpsertBatchSize, 1)))
+	workers := min(max(j.config.Worker.MaxWorkers, 1), max(len(batches), 1))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan []entity.ExternalItem)
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range queue {
+				results, err := j.itemRepository.UpsertManyWithHash(ctx, j.apiType, batch)
+
+				mu.Lock()
+				for _, result := range results {
+					stats.Add(result)
+				}
+				if err != nil {
+					lastErr = err
+				}
+				mu.Unlock()
+
+				for _, result := range results {
+					if result.Outcome == entity.UpsertFailed {
+						j.logger.Error("Failed to store item", "api_type", j.apiType, "id", result.ExternalID, "error", result.Err)
+					}
+				}
+				j.logger.Debug("Stored item batch", "api_type", j.apiType, "batch_size", len(batch))
+			}
+		}()
+	}
+
+	cancelled := false
+feed:
+	for _, batch := range batches {
 		select {
 		case <-ctx.Done():
-			lastErr = ctx.Err()
-			return
-		default:
+			cancelled = true
+			break feed
+		case queue <- batch:
 		}
 	}
+	close(queue)
+	wg.Wait()
 
+	if cancelled {
+		lastErr = ctx.Err()
+	}
 	return
 }
@@ -0,0 +1,792 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/internal/item/provider"
+	"github.com/zainokta/item-sync/internal/item/strategy"
+	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/pkg/fakeupstream"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/metrics"
+)
+
+type staticStrategy struct {
+	items []entity.ExternalItem
+	err   error
+}
+
+func (s *staticStrategy) FetchAllItems(ctx context.Context, request strategy.SyncItemsRequest) ([]entity.ExternalItem, error) {
+	return s.items, s.err
+}
+
+func (s *staticStrategy) Fetch(ctx context.Context, request strategy.SyncItemsRequest) ([]entity.ExternalItem, error) {
+	return s.items, nil
+}
+
+type recordingSaver struct {
+	mu          sync.Mutex
+	saved       []int
+	failIDs     map[int]bool
+	outcomes    map[int]entity.UpsertOutcome
+	delay       time.Duration
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+
+	reconciled     bool
+	seenIDs        []int
+	deletionPolicy entity.DeletionPolicy
+	missing        int
+}
+
+func (s *recordingSaver) FindByAPISource(ctx context.Context, apiSource string, limit, offset int) ([]entity.Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []entity.Item
+	for _, id := range s.saved {
+		if len(items) == limit {
+			break
+		}
+		items = append(items, entity.Item{ExternalID: id, APISource: apiSource})
+	}
+	return items, nil
+}
+
+func (s *recordingSaver) ReconcileMissingItems(ctx context.Context, apiSource string, seenIDs []int, policy entity.DeletionPolicy) (int, error) {
+	s.reconciled = true
+	s.seenIDs = seenIDs
+	s.deletionPolicy = policy
+	return s.missing, nil
+}
+
+func (s *recordingSaver) UpsertManyWithHash(ctx context.Context, apiSource string, items []entity.ExternalItem) ([]entity.UpsertResult, error) {
+	current := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.maxInFlight.Load()
+		if current <= peak || s.maxInFlight.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var lastErr error
+	results := make([]entity.UpsertResult, len(items))
+	for i, item := range items {
+		results[i] = entity.UpsertResult{ExternalID: item.ID, Outcome: entity.UpsertInserted}
+		if outcome, ok := s.outcomes[item.ID]; ok {
+			results[i].Outcome = outcome
+		}
+		if s.failIDs[item.ID] {
+			lastErr = errors.New("write failed")
+			results[i].Outcome = entity.UpsertFailed
+			results[i].Err = lastErr
+			continue
+		}
+
+		s.mu.Lock()
+		s.saved = append(s.saved, item.ID)
+		s.mu.Unlock()
+	}
+	return results, lastErr
+}
+
+// recordingCache records the invalidated keys
+type recordingCache struct {
+	invalidated []string
+}
+
+func (c *recordingCache) GetItems(ctx context.Context, key string) ([]entity.Item, error) {
+	return nil, errors.New("cache miss")
+}
+
+func (c *recordingCache) SetItems(ctx context.Context, key string, items []entity.Item, ttl time.Duration) error {
+	return nil
+}
+
+func (c *recordingCache) GetItem(ctx context.Context, key string) (entity.Item, error) {
+	return entity.Item{}, errors.New("cache miss")
+}
+
+func (c *recordingCache) SetItem(ctx context.Context, key string, item entity.Item, ttl time.Duration) error {
+	return nil
+}
+
+func (c *recordingCache) Invalidate(ctx context.Context, key string) error {
+	c.invalidated = append(c.invalidated, key)
+	return nil
+}
+
+type memoryJobRepository struct {
+	checkpoint *entity.SyncCheckpoint
+	deleted    bool
+	status     string
+}
+
+func (r *memoryJobRepository) CreateSyncJobRecord(ctx context.Context, name string, apiType string) (int64, error) {
+	return 1, nil
+}
+
+func (r *memoryJobRepository) UpdateSyncJobRecord(ctx context.Context, jobID int64, status string, stats entity.SyncJobStats, lastErr error, executionTime time.Duration) error {
+	r.status = status
+	return nil
+}
+
+func (r *memoryJobRepository) FindSyncCheckpoint(ctx context.Context, apiSource string) (*entity.SyncCheckpoint, error) {
+	if r.checkpoint == nil {
+		return nil, nil
+	}
+	checkpoint := *r.checkpoint
+	return &checkpoint, nil
+}
+
+func (r *memoryJobRepository) SaveSyncCheckpoint(ctx context.Context, checkpoint entity.SyncCheckpoint) error {
+	r.checkpoint = &checkpoint
+	return nil
+}
+
+func (r *memoryJobRepository) DeleteSyncCheckpoint(ctx context.Context, apiSource string) error {
+	r.checkpoint = nil
+	r.deleted = true
+	return nil
+}
+
+// pagedStrategy returns one page per checkpoint offset and fails at failAt
+type pagedStrategy struct {
+	pages  [][]entity.ExternalItem
+	failAt int
+	starts []int
+}
+
+func (s *pagedStrategy) FetchAllItems(ctx context.Context, request strategy.SyncItemsRequest) ([]entity.ExternalItem, error) {
+	s.starts = append(s.starts, request.Checkpoint.Offset)
+
+	var items []entity.ExternalItem
+	for page := request.Checkpoint.Offset; page < len(s.pages); page++ {
+		if page == s.failAt {
+			return items, errors.New("upstream unavailable")
+		}
+		items = append(items, s.pages[page]...)
+		request.Checkpoint.Offset = page + 1
+	}
+	return items, nil
+}
+
+func (s *pagedStrategy) Fetch(ctx context.Context, request strategy.SyncItemsRequest) ([]entity.ExternalItem, error) {
+	return s.pages[0], nil
+}
+
+// streamingPagedStrategy streams one page per checkpoint offset and records how many items were stored
+// before each page was fetched. Pages are answered with the Last-Modified at their index, if any.
+type streamingPagedStrategy struct {
+	pagedStrategy
+	saver        *recordingSaver
+	storedBefore []int
+	lastModified []time.Time
+}
+
+func (s *streamingPagedStrategy) StreamAllItems(ctx context.Context, request strategy.SyncItemsRequest, handle strategy.PageHandler) error {
+	s.starts = append(s.starts, request.Checkpoint.Offset)
+
+	for page := request.Checkpoint.Offset; page < len(s.pages); page++ {
+		s.saver.mu.Lock()
+		s.storedBefore = append(s.storedBefore, len(s.saver.saved))
+		s.saver.mu.Unlock()
+
+		if page == s.failAt {
+			return errors.New("upstream unavailable")
+		}
+		var lastModified time.Time
+		if page < len(s.lastModified) {
+			lastModified = s.lastModified[page]
+		}
+		request.Checkpoint.Offset = page + 1
+		if err := handle(ctx, strategy.Page{Items: s.pages[page], LastModified: lastModified, Last: page == len(s.pages)-1}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// endlessAPIClient serves a listing that always has a next page
+type endlessAPIClient struct {
+	calls int
+}
+
+func (c *endlessAPIClient) Fetch(ctx context.Context, apiName string, operation string, params map[string]interface{}) ([]entity.ExternalItem, error) {
+	return nil, nil
+}
+
+func (c *endlessAPIClient) FetchPaginated(ctx context.Context, apiName string, operation string, params map[string]interface{}) (*api.PaginatedResponse, error) {
+	c.calls++
+	return &api.PaginatedResponse{
+		Items:      []entity.ExternalItem{{ID: c.calls, Title: "item"}},
+		Pagination: api.NewPaginationMetadata(0, fmt.Sprintf("page-%d", c.calls+1), ""),
+	}, nil
+}
+
+func (c *endlessAPIClient) FetchByID(ctx context.Context, apiName string, id int) (entity.ExternalItem, error) {
+	return entity.ExternalItem{}, nil
+}
+
+func newTestItems(count int) []entity.ExternalItem {
+	items := make([]entity.ExternalItem, count)
+	for i := range items {
+		items[i] = entity.ExternalItem{ID: i + 1, Title: "item"}
+	}
+	return items
+}
+
+func newTestSyncJob(saver ItemSaver, items []entity.ExternalItem, maxWorkers, batchSize int) *SyncJob {
+	return NewSyncJob(
+		"test",
+		saver,
+		&memoryJobRepository{},
+		&recordingCache{},
+		nil,
+		&staticStrategy{items: items},
+		"pokemon",
+		"list",
+		logger.NewLogger(logger.LevelError, "test"),
+		config.Config{
+			Worker:   config.WorkerConfig{MaxWorkers: maxWorkers},
+			Database: config.DatabaseConfig{UpsertBatchSize: batchSize},
+		},
+		nil,
+	)
+}
+
+func TestSyncJob_syncItems_BoundedWorkers(t *testing.T) {
+	saver := &recordingSaver{
+		failIDs:  map[int]bool{3: true, 7: true},
+		outcomes: map[int]entity.UpsertOutcome{1: entity.UpsertUpdated, 2: entity.UpsertUnchanged, 4: entity.UpsertUnchanged},
+		delay:    5 * time.Millisecond,
+	}
+	job := newTestSyncJob(saver, newTestItems(20), 4, 2)
+
+	stats, err := job.syncItems(context.Background())
+
+	assert.Error(t, err, "Failed items should be reported")
+	assert.Equal(t, entity.SyncJobStats{
+		Processed: 20,
+		Succeeded: 18,
+		Failed:    2,
+		Inserted:  15,
+		Updated:   1,
+		Unchanged: 2,
+	}, stats)
+	assert.Len(t, saver.saved, 18)
+	assert.LessOrEqual(t, saver.maxInFlight.Load(), int32(4), "Should not exceed MaxWorkers concurrent batches")
+	assert.Greater(t, saver.maxInFlight.Load(), int32(1), "Should write batches concurrently")
+}
+
+func TestSyncJob_storeItems_SkipsIncompleteItems(t *testing.T) {
+	saver := &recordingSaver{}
+	items := newTestItems(3)
+	items[1].Incomplete = true
+	job := newTestSyncJob(saver, nil, 2, 10)
+
+	stats, err := job.storeItems(context.Background(), items)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.SyncJobStats{Processed: 3, Succeeded: 2, Failed: 1, Inserted: 2}, stats)
+	assert.ElementsMatch(t, []int{1, 3}, saver.saved, "An incomplete item should not overwrite the stored one")
+	assert.True(t, items[1].Incomplete, "Input items should not be modified")
+}
+
+func TestSyncJob_syncItems_StopsOnCancellation(t *testing.T) {
+	saver := &recordingSaver{delay: 20 * time.Millisecond}
+	job := newTestSyncJob(saver, newTestItems(100), 2, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	stats, err := job.syncItems(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "Should stop promptly")
+	assert.Less(t, stats.Processed, 100, "Batches not yet handed to a worker should be dropped")
+	assert.Equal(t, stats.Processed, stats.Succeeded+stats.Failed)
+}
+
+func TestSyncJob_syncItems_ReconcilesMissingItemsAfterFullSync(t *testing.T) {
+	saver := &recordingSaver{}
+	job := newTestSyncJob(saver, newTestItems(3), 2, 2)
+	job.config.Worker.DefaultDeletionPolicy = "flag-only"
+	job.config.Worker.DeletionPolicy = map[string]string{"pokemon": "soft-delete"}
+
+	_, err := job.syncItems(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, saver.reconciled)
+	assert.Equal(t, []int{1, 2, 3}, saver.seenIDs)
+	assert.Equal(t, entity.DeletionSoftDelete, saver.deletionPolicy)
+}
+
+func TestSyncJob_syncItems_InvalidatesListingsAfterReconciliation(t *testing.T) {
+	tests := []struct {
+		name        string
+		missing     int
+		invalidated []string
+	}{
+		{name: "items missing", missing: 2, invalidated: entity.ItemListCachePatterns("pokemon")},
+		{name: "nothing missing", missing: 0, invalidated: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saver := &recordingSaver{missing: tt.missing}
+			job := newTestSyncJob(saver, newTestItems(3), 2, 2)
+
+			_, err := job.syncItems(context.Background())
+
+			require.NoError(t, err)
+			assert.True(t, saver.reconciled)
+			assert.Equal(t, tt.invalidated, job.itemCache.(*recordingCache).invalidated)
+		})
+	}
+}
+
+func TestSyncJob_syncItems_SkipsReconciliationForIncompleteRuns(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		items  []entity.ExternalItem
+		err    error
+	}{
+		{name: "limited fetch", params: map[string]interface{}{"limit": 2}, items: newTestItems(2)},
+		{name: "fetch error", items: newTestItems(2), err: errors.New("page 3 failed")},
+		{name: "empty listing", items: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saver := &recordingSaver{}
+			job := newTestSyncJob(saver, tt.items, 2, 2)
+			job.syncStrategy = &staticStrategy{items: tt.items, err: tt.err}
+			if tt.params != nil {
+				job.params = tt.params
+			}
+
+			_, _ = job.syncItems(context.Background())
+
+			assert.False(t, saver.reconciled, "Only a complete listing should mark items as missing")
+		})
+	}
+}
+
+func TestSyncJob_syncItems_SkipsReconciliationForPartialListings(t *testing.T) {
+	tests := []struct {
+		name      string
+		narrowed  bool
+		streaming strategy.SyncStrategy
+	}{
+		{name: "caller narrowed city list", narrowed: provider.OpenWeather().NarrowsListing(map[string]interface{}{"cities": "London"})},
+		{name: "caller set offset", narrowed: provider.Pokemon().NarrowsListing(map[string]interface{}{"offset": float64(100)})},
+		{name: "max pages reached", streaming: strategy.NewPaginatedSyncStrategy(nil, &endlessAPIClient{}, 2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saver := &recordingSaver{}
+			job := newTestSyncJob(saver, newTestItems(3), 2, 2).WithNarrowedParams(tt.narrowed)
+			if tt.streaming != nil {
+				job.syncStrategy = tt.streaming
+			}
+
+			stats, err := job.syncItems(context.Background())
+
+			require.NoError(t, err)
+			assert.Positive(t, stats.Succeeded)
+			assert.False(t, saver.reconciled, "Items outside of a partial listing are not missing upstream")
+		})
+	}
+}
+
+func TestSyncJob_syncItems_ResumesFromCheckpoint(t *testing.T) {
+	saver := &recordingSaver{}
+	jobRepo := &memoryJobRepository{}
+	pages := &pagedStrategy{
+		pages:  [][]entity.ExternalItem{newTestItems(2), {{ID: 3, Title: "item"}}, {{ID: 4, Title: "item"}}},
+		failAt: 2,
+	}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.jobRepository = jobRepo
+	job.syncStrategy = pages
+
+	// First run fails on the last page and keeps the position after the stored pages
+	_, err := job.syncItems(entity.WithSyncJobID(context.Background(), 7))
+
+	assert.Error(t, err)
+	if assert.NotNil(t, jobRepo.checkpoint) {
+		assert.Equal(t, 2, jobRepo.checkpoint.Offset)
+		assert.Equal(t, int64(7), jobRepo.checkpoint.SyncJobID)
+	}
+
+	// Second run resumes, completes the pass and clears the checkpoint
+	pages.failAt = -1
+	_, err = job.syncItems(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, pages.starts)
+	assert.Nil(t, jobRepo.checkpoint)
+	assert.True(t, jobRepo.deleted)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, saver.saved)
+	assert.False(t, saver.reconciled, "A resumed pass did not see every item")
+}
+
+func TestSyncJob_syncItems_NarrowedRunsKeepOwnPosition(t *testing.T) {
+	saver := &recordingSaver{}
+	jobRepo := &memoryJobRepository{checkpoint: &entity.SyncCheckpoint{APISource: "pokemon", Offset: 1}}
+	pages := &pagedStrategy{
+		pages:  [][]entity.ExternalItem{newTestItems(2), {{ID: 3, Title: "item"}}, {{ID: 4, Title: "item"}}},
+		failAt: 2,
+	}
+	narrowed := newTestSyncJob(saver, nil, 2, 10).WithNarrowedParams(true)
+	narrowed.jobRepository = jobRepo
+	narrowed.syncStrategy = pages
+
+	// A failing narrowed run starts from the beginning and does not move the default checkpoint
+	_, err := narrowed.syncItems(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []int{0}, pages.starts, "A narrowed run should not resume the default pass")
+	assert.Equal(t, 1, jobRepo.checkpoint.Offset)
+
+	// A completed narrowed run does not clear it either
+	pages.failAt = -1
+	_, err = narrowed.syncItems(context.Background())
+	assert.NoError(t, err)
+	if assert.NotNil(t, jobRepo.checkpoint) {
+		assert.Equal(t, 1, jobRepo.checkpoint.Offset)
+	}
+	assert.False(t, jobRepo.deleted)
+
+	// The default run still resumes from its own position
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.jobRepository = jobRepo
+	job.syncStrategy = pages
+	_, err = job.syncItems(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 0, 1}, pages.starts)
+	assert.True(t, jobRepo.deleted)
+}
+
+func TestSyncJob_syncItems_KeepsCheckpointWhenStoreFails(t *testing.T) {
+	saver := &recordingSaver{failIDs: map[int]bool{3: true}}
+	jobRepo := &memoryJobRepository{checkpoint: &entity.SyncCheckpoint{APISource: "pokemon", Offset: 1}}
+	pages := &pagedStrategy{
+		pages:  [][]entity.ExternalItem{newTestItems(2), {{ID: 3, Title: "item"}}, {{ID: 4, Title: "item"}}},
+		failAt: 2,
+	}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.jobRepository = jobRepo
+	job.syncStrategy = pages
+
+	_, err := job.syncItems(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, []int{1}, pages.starts)
+	assert.Equal(t, 1, jobRepo.checkpoint.Offset, "Pages with unstored items must be fetched again")
+}
+
+func TestSyncJob_syncItems_StreamsPages(t *testing.T) {
+	saver := &recordingSaver{failIDs: map[int]bool{4: true}}
+	jobRepo := &memoryJobRepository{}
+	pages := &streamingPagedStrategy{
+		pagedStrategy: pagedStrategy{
+			pages: [][]entity.ExternalItem{
+				newTestItems(2),
+				{{ID: 3, Title: "item"}},
+				{{ID: 4, Title: "item"}},
+				{{ID: 5, Title: "item"}},
+				{{ID: 6, Title: "item"}},
+			},
+			failAt: 4,
+		},
+		saver: saver,
+	}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.jobRepository = jobRepo
+	job.syncStrategy = pages
+
+	stats, err := job.syncItems(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, []int{0, 2, 3, 3, 4}, pages.storedBefore, "Every page should be stored before the next one is fetched")
+	assert.Equal(t, entity.SyncJobStats{Processed: 5, Succeeded: 4, Failed: 1, Inserted: 4}, stats)
+	assert.ElementsMatch(t, []int{1, 2, 3, 5}, saver.saved, "Pages before a late failure should be kept")
+	if assert.NotNil(t, jobRepo.checkpoint) {
+		assert.Equal(t, 2, jobRepo.checkpoint.Offset, "Checkpoint should stop before the page with an unstored item")
+	}
+	assert.False(t, saver.reconciled)
+}
+
+func TestSyncJob_syncItems_StreamingCompletesPass(t *testing.T) {
+	saver := &recordingSaver{}
+	jobRepo := &memoryJobRepository{}
+	pages := &streamingPagedStrategy{
+		pagedStrategy: pagedStrategy{pages: [][]entity.ExternalItem{newTestItems(2), {{ID: 3, Title: "item"}}}, failAt: -1},
+		saver:         saver,
+	}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.jobRepository = jobRepo
+	job.syncStrategy = pages
+
+	stats, err := job.syncItems(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.Succeeded)
+	assert.Nil(t, jobRepo.checkpoint)
+	assert.True(t, jobRepo.deleted)
+	assert.True(t, saver.reconciled)
+	assert.Equal(t, []int{1, 2, 3}, saver.seenIDs)
+}
+
+func TestSyncJob_syncItems_SavesWatermark(t *testing.T) {
+	saver := &recordingSaver{}
+	jobRepo := &memoryJobRepository{}
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	pages := &streamingPagedStrategy{
+		pagedStrategy: pagedStrategy{pages: [][]entity.ExternalItem{newTestItems(2), {{ID: 3, Title: "item"}}, {{ID: 4, Title: "item"}}}, failAt: 2},
+		saver:         saver,
+		lastModified:  []time.Time{monday.Add(time.Hour), monday},
+	}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.jobRepository = jobRepo
+	job.syncStrategy = pages
+
+	_, err := job.syncItems(context.Background())
+
+	assert.Error(t, err)
+	if assert.NotNil(t, jobRepo.checkpoint) && assert.NotNil(t, jobRepo.checkpoint.Watermark) {
+		assert.Equal(t, 2, jobRepo.checkpoint.Offset)
+		assert.Equal(t, monday.Add(time.Hour), *jobRepo.checkpoint.Watermark, "The watermark should be the newest Last-Modified of the stored pages")
+	}
+}
+
+func TestSyncJob_syncItems_StartsOverWhenListingChanged(t *testing.T) {
+	saver := &recordingSaver{}
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	jobRepo := &memoryJobRepository{checkpoint: &entity.SyncCheckpoint{APISource: "pokemon", Offset: 1, Watermark: &monday}}
+	pages := &streamingPagedStrategy{
+		pagedStrategy: pagedStrategy{pages: [][]entity.ExternalItem{newTestItems(2), {{ID: 3, Title: "item"}}}, failAt: -1},
+		saver:         saver,
+		lastModified:  []time.Time{monday.Add(time.Hour), monday.Add(time.Hour)},
+	}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.jobRepository = jobRepo
+	job.syncStrategy = pages
+
+	stats, err := job.syncItems(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, pages.starts, "A page modified after the watermark should restart the pass from the beginning")
+	assert.Equal(t, 3, stats.Succeeded, "The changed page should only be stored by the restarted pass")
+	assert.Nil(t, jobRepo.checkpoint)
+	assert.True(t, saver.reconciled, "The restarted pass saw every item")
+	assert.Equal(t, []int{1, 2, 3}, saver.seenIDs)
+}
+
+func TestSyncJob_syncItems_ResumesWhenListingUnchanged(t *testing.T) {
+	saver := &recordingSaver{}
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	jobRepo := &memoryJobRepository{checkpoint: &entity.SyncCheckpoint{APISource: "pokemon", Offset: 1, Watermark: &monday}}
+	pages := &streamingPagedStrategy{
+		pagedStrategy: pagedStrategy{pages: [][]entity.ExternalItem{newTestItems(2), {{ID: 3, Title: "item"}}}, failAt: -1},
+		saver:         saver,
+		lastModified:  []time.Time{monday, monday},
+	}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.jobRepository = jobRepo
+	job.syncStrategy = pages
+
+	_, err := job.syncItems(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, pages.starts)
+	assert.Equal(t, []int{3}, saver.saved)
+	assert.False(t, saver.reconciled)
+}
+
+func TestSyncJob_syncItems_AgainstFakeUpstream(t *testing.T) {
+	server, _ := fakeupstream.NewServer(fakeupstream.DefaultCatalog())
+	defer server.Close()
+	t.Setenv("API_POKEMON_BASE_URL", server.URL+fakeupstream.PokemonPath)
+
+	log := logger.NewLogger(logger.LevelError, "test")
+	syncStrategy, err := provider.Pokemon().NewSyncStrategy(
+		config.APIConfig{Timeout: 5 * time.Second, PokemonEnrichDetails: true, PokemonEnrichConcurrency: 4, PokemonEnrichRefresh: time.Hour},
+		config.RetryConfig{CircuitThreshold: 5, CircuitTimeout: time.Minute},
+		log,
+	)
+	require.NoError(t, err)
+
+	saver := &recordingSaver{}
+	jobRepo := &memoryJobRepository{}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.jobRepository = jobRepo
+	job.syncStrategy = syncStrategy
+
+	stats, err := job.syncItems(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 25, stats.Succeeded)
+	assert.Len(t, saver.saved, 25, "Every pokemon of the catalog should be stored")
+	assert.True(t, saver.reconciled, "A full offline pass should reconcile")
+	assert.Nil(t, jobRepo.checkpoint)
+}
+
+type memoryResponseCache struct {
+	mu        sync.Mutex
+	responses map[string]api.CachedResponse
+}
+
+func (c *memoryResponseCache) GetResponse(ctx context.Context, url string) (*api.CachedResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	response, ok := c.responses[url]
+	if !ok {
+		return nil, nil
+	}
+	return &response, nil
+}
+
+func (c *memoryResponseCache) SetResponse(ctx context.Context, url string, response api.CachedResponse, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[url] = response
+	return nil
+}
+
+func TestSyncJob_syncItems_SkipsPagesNotModified(t *testing.T) {
+	server, handler := fakeupstream.NewServer(fakeupstream.DefaultCatalog())
+	defer server.Close()
+	t.Setenv("API_POKEMON_BASE_URL", server.URL+fakeupstream.PokemonPath)
+
+	api.UseResponseCache(&memoryResponseCache{responses: make(map[string]api.CachedResponse)})
+	t.Cleanup(func() { api.UseResponseCache(nil) })
+
+	syncStrategy, err := provider.Pokemon().NewSyncStrategy(
+		config.APIConfig{Timeout: 5 * time.Second, ConditionalRequests: true, ConditionalCacheTTL: time.Hour},
+		config.RetryConfig{CircuitThreshold: 5, CircuitTimeout: time.Minute},
+		logger.NewLogger(logger.LevelError, "test"),
+	)
+	require.NoError(t, err)
+
+	saver := &recordingSaver{}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.syncStrategy = syncStrategy
+
+	stats, err := job.syncItems(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 25, stats.Succeeded)
+	assert.Zero(t, stats.PagesNotModified)
+
+	stats, err = job.syncItems(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entity.SyncJobStats{PagesNotModified: 2}, stats, "Unchanged pages should not be stored again")
+	assert.Len(t, saver.saved, 25)
+	assert.Len(t, saver.seenIDs, 25, "Items of unchanged pages should still count as seen")
+
+	// Renaming the first pokemon only changes the first page
+	catalog := fakeupstream.DefaultCatalog()
+	catalog.Pokemon[0].Name = "bulbasaur-renamed"
+	handler.SetCatalog(catalog)
+
+	stats, err = job.syncItems(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 20, stats.Processed)
+	assert.Equal(t, 1, stats.PagesNotModified)
+}
+
+func TestSyncJob_syncItems_RefetchesPagesNotStored(t *testing.T) {
+	server, _ := fakeupstream.NewServer(fakeupstream.DefaultCatalog())
+	defer server.Close()
+	t.Setenv("API_POKEMON_BASE_URL", server.URL+fakeupstream.PokemonPath)
+
+	api.UseResponseCache(&memoryResponseCache{responses: make(map[string]api.CachedResponse)})
+	t.Cleanup(func() { api.UseResponseCache(nil) })
+
+	syncStrategy, err := provider.Pokemon().NewSyncStrategy(
+		config.APIConfig{Timeout: 5 * time.Second, ConditionalRequests: true, ConditionalCacheTTL: time.Hour},
+		config.RetryConfig{CircuitThreshold: 5, CircuitTimeout: time.Minute},
+		logger.NewLogger(logger.LevelError, "test"),
+	)
+	require.NoError(t, err)
+
+	// An item of the second page fails to store
+	saver := &recordingSaver{failIDs: map[int]bool{21: true}}
+	job := newTestSyncJob(saver, nil, 2, 10)
+	job.syncStrategy = syncStrategy
+
+	_, err = job.syncItems(context.Background())
+	require.Error(t, err)
+
+	// The resumed pass gets the failed page in full
+	saver.failIDs = nil
+	stats, err := job.syncItems(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, stats.PagesNotModified, "A page with unstored items should not be answered with 304")
+	assert.Equal(t, 5, stats.Processed)
+
+	stats, err = job.syncItems(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.PagesNotModified)
+
+	// A new database keeps the cached validators, every page has to be stored again
+	freshSaver := &recordingSaver{}
+	job.itemRepository = freshSaver
+
+	stats, err = job.syncItems(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, stats.PagesNotModified)
+	assert.Len(t, freshSaver.saved, 25)
+}
+
+func TestSyncJob_Run_RecordsMetrics(t *testing.T) {
+	saver := &recordingSaver{failIDs: map[int]bool{3: true}, outcomes: map[int]entity.UpsertOutcome{2: entity.UpsertUnchanged}}
+	job := newTestSyncJob(saver, newTestItems(4), 2, 10)
+
+	runs := testutil.ToFloat64(metrics.SyncJobRuns.WithLabelValues("pokemon", entity.SyncJobStatusFailed))
+	inserted := testutil.ToFloat64(metrics.SyncJobItems.WithLabelValues("pokemon", "inserted"))
+	unchanged := testutil.ToFloat64(metrics.SyncJobItems.WithLabelValues("pokemon", "unchanged"))
+	failed := testutil.ToFloat64(metrics.SyncJobItems.WithLabelValues("pokemon", "failed"))
+
+	err := job.Run(context.Background(), 1, nil)
+	require.Error(t, err)
+
+	assert.Equal(t, runs+1, testutil.ToFloat64(metrics.SyncJobRuns.WithLabelValues("pokemon", entity.SyncJobStatusFailed)))
+	assert.Equal(t, inserted+2, testutil.ToFloat64(metrics.SyncJobItems.WithLabelValues("pokemon", "inserted")))
+	assert.Equal(t, unchanged+1, testutil.ToFloat64(metrics.SyncJobItems.WithLabelValues("pokemon", "unchanged")))
+	assert.Equal(t, failed+1, testutil.ToFloat64(metrics.SyncJobItems.WithLabelValues("pokemon", "failed")))
+}
+
+func TestSyncJob_Run_RecordsLostLeaseAsCancelled(t *testing.T) {
+	job := newTestSyncJob(&recordingSaver{}, newTestItems(4), 2, 10)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(entity.ErrLeaseLost)
+
+	err := job.Run(ctx, 1, nil)
+
+	require.Error(t, err)
+	assert.Equal(t, entity.SyncJobStatusCancelled, job.jobRepository.(*memoryJobRepository).status)
+}
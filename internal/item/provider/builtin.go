@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/item/strategy"
+	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+// Pokemon syncs the PokeAPI pokemon catalogue
+func Pokemon() Provider {
+	return Provider{
+		Name:      "pokemon",
+		Operation: "list",
+		NewClient: func(apiConfig config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (api.ExternalAPIClient, error) {
+			return api.NewPokemonClient(apiConfig, retryConfig, logger), nil
+		},
+		NewStrategy: func(apiClient strategy.ExternalAPIClient, logger logger.Logger) strategy.SyncStrategy {
+			return strategy.NewPokemonSyncStrategy(logger, apiClient)
+		},
+	}
+}
+
+// OpenWeather syncs current weather for a list of cities
+func OpenWeather() Provider {
+	return Provider{
+		Name:      "openweather",
+		Operation: "weather",
+		DefaultParams: map[string]interface{}{
+			"cities": "Jakarta,Bandung,Surabaya",
+		},
+		NewClient: func(apiConfig config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (api.ExternalAPIClient, error) {
+			return api.NewOpenWeatherClient(apiConfig, retryConfig, logger), nil
+		},
+		NewStrategy: func(apiClient strategy.ExternalAPIClient, logger logger.Logger) strategy.SyncStrategy {
+			return strategy.NewOpenWeatherSyncStrategy(apiClient)
+		},
+	}
+}
+
+// NewDefaultRegistry returns a registry with the built-in providers registered
+func NewDefaultRegistry() *Registry {
+	registry := NewRegistry()
+	for _, p := range []Provider{Pokemon(), OpenWeather()} {
+		if err := registry.Register(p); err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
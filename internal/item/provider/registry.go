@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/item/strategy"
+	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+var (
+	ErrProviderNotFound      = errors.New("api source is not supported yet")
+	ErrProviderAlreadyExists = errors.New("provider already registered")
+)
+
+// ClientFactory builds the external API client of a provider
+type ClientFactory func(apiConfig config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (api.ExternalAPIClient, error)
+
+// StrategyFactory builds the sync strategy of a provider on top of its client
+type StrategyFactory func(apiClient strategy.ExternalAPIClient, logger logger.Logger) strategy.SyncStrategy
+
+// Provider describes an external data source and everything needed to sync it
+type Provider struct {
+	Name          string
+	Operation     string
+	DefaultParams map[string]interface{}
+	NewClient     ClientFactory
+	NewStrategy   StrategyFactory
+}
+
+// JobParams returns the provider default params overridden by the given params
+func (p Provider) JobParams(overrides map[string]interface{}) map[string]interface{} {
+	params := make(map[string]interface{}, len(p.DefaultParams)+len(overrides))
+	for k, v := range p.DefaultParams {
+		params[k] = v
+	}
+	for k, v := range overrides {
+		params[k] = v
+	}
+	return params
+}
+
+// NarrowsListing reports whether the given params change any of the provider default params. A sync with such
+// params, for example a single city or a later offset, does not list every item of the source.
+func (p Provider) NarrowsListing(overrides map[string]interface{}) bool {
+	for k, v := range overrides {
+		if def, ok := p.DefaultParams[k]; !ok || !reflect.DeepEqual(def, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Client builds the provider client with the shared settings overridden by the provider ones,
+// see config.APIConfig.ForSource and config.RetryConfig.ForSource
+func (p Provider) Client(apiConfig config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (api.ExternalAPIClient, error) {
+	apiConfig, err := apiConfig.ForSource(p.Name)
+	if err != nil {
+		return nil, err
+	}
+	retryConfig, err = retryConfig.ForSource(p.Name)
+	if err != nil {
+		return nil, err
+	}
+	return p.NewClient(apiConfig, retryConfig, logger)
+}
+
+// NewSyncStrategy builds the provider client and wraps it in the provider sync strategy
+func (p Provider) NewSyncStrategy(apiConfig config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (strategy.SyncStrategy, error) {
+	apiClient, err := p.Client(apiConfig, retryConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	return p.NewStrategy(apiClient, logger), nil
+}
+
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+
+	// Set by Build
+	clients map[string]api.ExternalAPIClient
+	logger  logger.Logger
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		providers: make(map[string]Provider),
+		clients:   make(map[string]api.ExternalAPIClient),
+	}
+}
+
+func (r *Registry) Register(p Provider) error {
+	if p.Name == "" {
+		return errors.New("provider name is required")
+	}
+	if p.NewClient == nil || p.NewStrategy == nil {
+		return fmt.Errorf("provider %s: client and strategy factories are required", p.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.providers[p.Name]; exists {
+		return fmt.Errorf("%w: %s", ErrProviderAlreadyExists, p.Name)
+	}
+
+	r.providers[p.Name] = p
+	return nil
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.providers[name]
+	if !ok {
+		return Provider{}, fmt.Errorf("%w: %s", ErrProviderNotFound, name)
+	}
+	return p, nil
+}
+
+// Names returns the registered provider names in a stable order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// All returns the registered providers ordered by name
+func (r *Registry) All() []Provider {
+	names := r.Names()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	providers := make([]Provider, 0, len(names))
+	for _, name := range names {
+		if p, ok := r.providers[name]; ok {
+			providers = append(providers, p)
+		}
+	}
+	return providers
+}
+
+// Build resolves the settings of every registered provider and builds its client, see Provider.Client. It is meant
+// to run once at startup: invalid API_<SOURCE>_* or RETRY_<SOURCE>_* overrides fail it, and the clients are reused
+// by Client and SyncStrategy afterwards.
+func (r *Registry) Build(apiConfig config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) error {
+	providers := r.All()
+	clients := make(map[string]api.ExternalAPIClient, len(providers))
+	for _, p := range providers {
+		apiClient, err := p.Client(apiConfig, retryConfig, logger)
+		if err != nil {
+			return fmt.Errorf("provider %s: %w", p.Name, err)
+		}
+		clients[p.Name] = apiClient
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients = clients
+	r.logger = logger
+	return nil
+}
+
+// Client returns the client Build made for the provider
+func (r *Registry) Client(name string) (api.ExternalAPIClient, error) {
+	if _, err := r.Get(name); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	apiClient, ok := r.clients[name]
+	if !ok {
+		return nil, fmt.Errorf("provider %s has no client, the registry was not built", name)
+	}
+	return apiClient, nil
+}
+
+// SyncStrategy wraps the client Build made for the provider in the provider sync strategy
+func (r *Registry) SyncStrategy(name string) (strategy.SyncStrategy, error) {
+	p, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	apiClient, err := r.Client(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return p.NewStrategy(apiClient, r.logger), nil
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/item/strategy"
+	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+func TestNewDefaultRegistry(t *testing.T) {
+	registry := NewDefaultRegistry()
+
+	assert.Equal(t, []string{"openweather", "pokemon"}, registry.Names(), "Built-in providers should be registered")
+
+	pokemon, err := registry.Get("pokemon")
+	require.NoError(t, err)
+	assert.Equal(t, "list", pokemon.Operation)
+
+	openWeather, err := registry.Get("openweather")
+	require.NoError(t, err)
+	assert.Equal(t, "weather", openWeather.Operation)
+	assert.Equal(t, "Jakarta,Bandung,Surabaya", openWeather.DefaultParams["cities"])
+}
+
+func TestRegistry_Get_Unknown(t *testing.T) {
+	registry := NewDefaultRegistry()
+
+	_, err := registry.Get("unknown")
+	assert.ErrorIs(t, err, ErrProviderNotFound)
+
+	_, err = registry.Client("unknown")
+	assert.ErrorIs(t, err, ErrProviderNotFound)
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry()
+
+	custom := Provider{
+		Name:      "custom",
+		Operation: "list",
+		NewClient: func(apiConfig config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (api.ExternalAPIClient, error) {
+			return nil, nil
+		},
+		NewStrategy: func(apiClient strategy.ExternalAPIClient, logger logger.Logger) strategy.SyncStrategy {
+			return strategy.NewOpenWeatherSyncStrategy(apiClient)
+		},
+	}
+
+	require.NoError(t, registry.Register(custom))
+	assert.ErrorIs(t, registry.Register(custom), ErrProviderAlreadyExists, "Duplicate names should be rejected")
+	assert.Error(t, registry.Register(Provider{Name: "incomplete"}), "Providers without factories should be rejected")
+
+	_, err := registry.SyncStrategy("custom")
+	assert.Error(t, err, "Clients should only be available once the registry is built")
+
+	require.NoError(t, registry.Build(config.APIConfig{}, config.RetryConfig{}, nil))
+	syncStrategy, err := registry.SyncStrategy("custom")
+	require.NoError(t, err)
+	assert.NotNil(t, syncStrategy)
+	assert.Len(t, registry.All(), 1)
+}
+
+func TestProvider_JobParams(t *testing.T) {
+	p := OpenWeather()
+
+	params := p.JobParams(map[string]interface{}{"cities": "Tokyo", "units": "metric"})
+
+	assert.Equal(t, "Tokyo", params["cities"], "Overrides should win over defaults")
+	assert.Equal(t, "metric", params["units"])
+	assert.Equal(t, "Jakarta,Bandung,Surabaya", p.DefaultParams["cities"], "Defaults should not be mutated")
+}
+
+func TestProvider_NarrowsListing(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  Provider
+		overrides map[string]interface{}
+		narrowed  bool
+	}{
+		{name: "no overrides", provider: OpenWeather(), overrides: nil, narrowed: false},
+		{name: "default cities", provider: OpenWeather(), overrides: map[string]interface{}{"cities": "Jakarta,Bandung,Surabaya"}, narrowed: false},
+		{name: "single city", provider: OpenWeather(), overrides: map[string]interface{}{"cities": "London"}, narrowed: true},
+		{name: "offset", provider: Pokemon(), overrides: map[string]interface{}{"offset": float64(100)}, narrowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.narrowed, tt.provider.NarrowsListing(tt.overrides))
+		})
+	}
+}
+
+func TestRegistry_Build_SourceOverrides(t *testing.T) {
+	t.Setenv("API_CUSTOM_TIMEOUT", "3s")
+	t.Setenv("RETRY_CUSTOM_MAX_RETRIES", "9")
+
+	var gotAPI config.APIConfig
+	var gotRetry config.RetryConfig
+	builds := 0
+
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(Provider{
+		Name: "custom",
+		NewClient: func(apiConfig config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (api.ExternalAPIClient, error) {
+			gotAPI, gotRetry = apiConfig, retryConfig
+			builds++
+			return &api.OpenWeatherClient{}, nil
+		},
+		NewStrategy: func(apiClient strategy.ExternalAPIClient, logger logger.Logger) strategy.SyncStrategy {
+			return strategy.NewOpenWeatherSyncStrategy(apiClient)
+		},
+	}))
+
+	require.NoError(t, registry.Build(config.APIConfig{Timeout: 30 * time.Second, RateLimit: 100}, config.RetryConfig{MaxRetries: 5}, nil))
+
+	assert.Equal(t, 3*time.Second, gotAPI.Timeout, "Client should get the source timeout")
+	assert.Equal(t, 100, gotAPI.RateLimit, "Client should keep the shared settings without override")
+	assert.Equal(t, 9, gotRetry.MaxRetries)
+
+	// Later overrides are not picked up, the client built at startup is reused
+	t.Setenv("API_CUSTOM_TIMEOUT", "7s")
+	first, err := registry.Client("custom")
+	require.NoError(t, err)
+	second, err := registry.Client("custom")
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, builds)
+}
+
+func TestRegistry_Build_InvalidOverride(t *testing.T) {
+	t.Setenv("RETRY_POKEMON_MAX_RETRIES", "many")
+
+	err := NewDefaultRegistry().Build(config.APIConfig{}, config.RetryConfig{}, nil)
+
+	require.Error(t, err, "An invalid override should fail the startup")
+	assert.Contains(t, err.Error(), "pokemon")
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/item/strategy"
+	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+// REST builds a provider for a declaratively configured REST API
+func REST(restConfig api.RESTProviderConfig) Provider {
+	return Provider{
+		Name:          restConfig.Name,
+		Operation:     restConfig.DefaultOperation,
+		DefaultParams: restConfig.DefaultParams,
+		NewClient: func(apiConfig config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (api.ExternalAPIClient, error) {
+			return api.NewRESTClient(restConfig, apiConfig, retryConfig, logger)
+		},
+		NewStrategy: func(apiClient strategy.ExternalAPIClient, logger logger.Logger) strategy.SyncStrategy {
+			return strategy.NewPaginatedSyncStrategy(logger, apiClient, restConfig.Pagination.MaxPages)
+		},
+	}
+}
+
+// RegisterRESTProviders loads REST provider definitions from a YAML or JSON file into the registry
+func (r *Registry) RegisterRESTProviders(path string) ([]string, error) {
+	restConfigs, err := api.LoadRESTProviderConfigs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(restConfigs))
+	for _, restConfig := range restConfigs {
+		if err := r.Register(REST(restConfig)); err != nil {
+			return nil, err
+		}
+		names = append(names, restConfig.Name)
+	}
+
+	return names, nil
+}
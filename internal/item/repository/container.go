@@ -6,20 +6,25 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"github.com/zainokta/item-sync/internal/item/usecase"
+	"github.com/zainokta/item-sync/pkg/api"
 	"github.com/zainokta/item-sync/pkg/logger"
 )
 
 type RepositoryContainer struct {
-	ItemRepository usecase.ItemRepository
-	JobRepository  usecase.JobRepository
-	ItemCache      usecase.ItemCache
+	ItemRepository        usecase.ItemRepository
+	ItemVersionRepository usecase.ItemVersionRepository
+	JobRepository         usecase.JobRepository
+	ItemCache             usecase.ItemCache
+	ResponseCache         api.ResponseCache
 }
 
-func NewRepositoryContainer(db *sql.DB, redis *redis.Client, cacheTTL time.Duration, logger logger.Logger) *RepositoryContainer {
+func NewRepositoryContainer(db *sql.DB, redis *redis.Client, cacheTTL time.Duration, upsertBatchSize int, logger logger.Logger) *RepositoryContainer {
 	return &RepositoryContainer{
-		ItemRepository: NewItemRepository(db, logger),
-		JobRepository:  NewJobRepository(db, logger),
-		ItemCache:      NewItemCache(redis, cacheTTL, logger),
+		ItemRepository:        NewItemRepository(db, upsertBatchSize, logger),
+		ItemVersionRepository: NewItemVersionRepository(db, logger),
+		JobRepository:         NewJobRepository(db, logger),
+		ItemCache:             NewItemCache(redis, cacheTTL, logger),
+		ResponseCache:         NewResponseCache(redis, logger),
 	}
 }
 
@@ -27,10 +32,17 @@ func (c *RepositoryContainer) GetItemRepository() usecase.ItemRepository {
 	return c.ItemRepository
 }
 
+func (c *RepositoryContainer) GetItemVersionRepository() usecase.ItemVersionRepository {
+	return c.ItemVersionRepository
+}
+
 func (c *RepositoryContainer) GetJobRepository() usecase.JobRepository {
 	return c.JobRepository
 }
 
 func (c *RepositoryContainer) GetItemCache() usecase.ItemCache {
 	return c.ItemCache
-}
\ No newline at end of file
+}
+func (c *RepositoryContainer) GetResponseCache() api.ResponseCache {
+	return c.ResponseCache
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/zainokta/item-sync/internal/item/entity"
 	"github.com/zainokta/item-sync/internal/item/usecase"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/metrics"
 )
 
 // Ensure the cache implements the required interface
@@ -36,19 +37,23 @@ func (c *ItemCache) GetItems(ctx context.Context, key string) ([]entity.Item, er
 	if err != nil {
 		if err == redis.Nil {
 			c.logger.Debug("Cache miss", "key", key)
+			metrics.CacheRequests.WithLabelValues("items", "miss").Inc()
 			return nil, redis.Nil
 		}
 		c.logger.Error("Cache get items failed", "key", key, "error", err.Error())
+		metrics.CacheRequests.WithLabelValues("items", "error").Inc()
 		return nil, errors.CacheFailed(err)
 	}
 
 	var items []entity.Item
 	if err := json.Unmarshal(data, &items); err != nil {
 		c.logger.Error("Cache unmarshal failed", "key", key, "error", err.Error())
+		metrics.CacheRequests.WithLabelValues("items", "error").Inc()
 		return nil, errors.CacheFailed(err)
 	}
 
 	c.logger.Debug("Cache hit", "key", key, "items_count", len(items))
+	metrics.CacheRequests.WithLabelValues("items", "hit").Inc()
 	return items, nil
 }
 
@@ -108,19 +113,23 @@ func (c *ItemCache) GetItem(ctx context.Context, key string) (entity.Item, error
 	if err != nil {
 		if err == redis.Nil {
 			c.logger.Debug("Cache miss", "key", key)
+			metrics.CacheRequests.WithLabelValues("item", "miss").Inc()
 			return entity.Item{}, redis.Nil
 		}
 		c.logger.Error("Cache get item failed", "key", key, "error", err.Error())
+		metrics.CacheRequests.WithLabelValues("item", "error").Inc()
 		return entity.Item{}, errors.CacheFailed(err)
 	}
 
 	var item entity.Item
 	if err := json.Unmarshal(data, &item); err != nil {
 		c.logger.Error("Cache unmarshal item failed", "key", key, "error", err.Error())
+		metrics.CacheRequests.WithLabelValues("item", "error").Inc()
 		return entity.Item{}, errors.CacheFailed(err)
 	}
 
 	c.logger.Debug("Cache hit", "key", key, "item_id", item.ID)
+	metrics.CacheRequests.WithLabelValues("item", "hit").Inc()
 	return item, nil
 }
 
@@ -6,6 +6,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/zainokta/item-sync/internal/errors"
@@ -17,15 +19,23 @@ import (
 // Ensure the repository implements the required interfaces
 var _ usecase.ItemRepository = (*ItemRepository)(nil)
 
+const defaultUpsertBatchSize = 100
+
 type ItemRepository struct {
-	db     *sql.DB
-	logger logger.Logger
+	db        *sql.DB
+	batchSize int
+	logger    logger.Logger
 }
 
-func NewItemRepository(db *sql.DB, logger logger.Logger) *ItemRepository {
+func NewItemRepository(db *sql.DB, batchSize int, logger logger.Logger) *ItemRepository {
+	if batchSize <= 0 {
+		batchSize = defaultUpsertBatchSize
+	}
+
 	return &ItemRepository{
-		db:     db,
-		logger: logger,
+		db:        db,
+		batchSize: batchSize,
+		logger:    logger,
 	}
 }
 
@@ -71,19 +81,12 @@ func (r *ItemRepository) FindByID(ctx context.Context, id int) (entity.Item, err
 	r.logger.Debug("Repository find by ID", "id", id)
 
 	query := `
-		SELECT id, title, description, external_id, api_source, extend_info, last_synced_at, created_at, updated_at
+		SELECT id, title, description, external_id, api_source, extend_info, last_synced_at, created_at, updated_at, deleted_at, stale_at
 		FROM items 
 		WHERE id = ?
 	`
 
-	var item entity.Item
-	var extendInfoJSON string
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&item.ID, &item.Title, &item.Description, &item.ExternalID, &item.APISource,
-		&extendInfoJSON, &item.SyncedAt, &item.CreatedAt, &item.UpdatedAt,
-	)
-
+	item, err := scanItem(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			r.logger.Debug("Repository item not found", "id", id)
@@ -93,13 +96,6 @@ func (r *ItemRepository) FindByID(ctx context.Context, id int) (entity.Item, err
 		return entity.Item{}, errors.DatabaseError(err)
 	}
 
-	if extendInfoJSON != "" {
-		if err := json.Unmarshal([]byte(extendInfoJSON), &item.ExtendInfo); err != nil {
-			r.logger.Error("Repository unmarshal extend_info failed", "id", id, "error", err.Error())
-			return entity.Item{}, errors.DatabaseError(err)
-		}
-	}
-
 	r.logger.Debug("Repository find by ID success", "id", id, "external_id", item.ExternalID)
 	return item, nil
 }
@@ -108,8 +104,9 @@ func (r *ItemRepository) FindAll(ctx context.Context, limit, offset int) ([]enti
 	r.logger.Debug("Repository find all", "limit", limit, "offset", offset)
 
 	query := `
-		SELECT id, title, description, external_id, api_source, extend_info, last_synced_at, created_at, updated_at
+		SELECT id, title, description, external_id, api_source, extend_info, last_synced_at, created_at, updated_at, deleted_at, stale_at
 		FROM items 
+		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
 	`
@@ -121,28 +118,9 @@ func (r *ItemRepository) FindAll(ctx context.Context, limit, offset int) ([]enti
 	}
 	defer rows.Close()
 
-	var items []entity.Item
-	for rows.Next() {
-		var item entity.Item
-		var extendInfoJSON string
-
-		err := rows.Scan(
-			&item.ID, &item.Title, &item.Description, &item.ExternalID, &item.APISource,
-			&extendInfoJSON, &item.SyncedAt, &item.CreatedAt, &item.UpdatedAt,
-		)
-		if err != nil {
-			r.logger.Error("Repository scan item failed", "error", err.Error())
-			return nil, errors.DatabaseError(err)
-		}
-
-		if extendInfoJSON != "" {
-			if err := json.Unmarshal([]byte(extendInfoJSON), &item.ExtendInfo); err != nil {
-				r.logger.Error("Repository unmarshal extend_info failed", "id", item.ID, "error", err.Error())
-				return nil, errors.DatabaseError(err)
-			}
-		}
-
-		items = append(items, item)
+	items, err := r.scanItems(rows)
+	if err != nil {
+		return nil, err
 	}
 
 	r.logger.Debug("Repository find all success", "count", len(items))
@@ -153,9 +131,9 @@ func (r *ItemRepository) FindByAPISource(ctx context.Context, apiSource string,
 	r.logger.Debug("Repository find by API source", "api_source", apiSource, "limit", limit, "offset", offset)
 
 	query := `
-		SELECT id, title, description, external_id, api_source, extend_info, last_synced_at, created_at, updated_at
+		SELECT id, title, description, external_id, api_source, extend_info, last_synced_at, created_at, updated_at, deleted_at, stale_at
 		FROM items 
-		WHERE api_source = ?
+		WHERE api_source = ? AND deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
 	`
@@ -167,28 +145,9 @@ func (r *ItemRepository) FindByAPISource(ctx context.Context, apiSource string,
 	}
 	defer rows.Close()
 
-	var items []entity.Item
-	for rows.Next() {
-		var item entity.Item
-		var extendInfoJSON string
-
-		err := rows.Scan(
-			&item.ID, &item.Title, &item.Description, &item.ExternalID, &item.APISource,
-			&extendInfoJSON, &item.SyncedAt, &item.CreatedAt, &item.UpdatedAt,
-		)
-		if err != nil {
-			r.logger.Error("Repository scan item failed", "error", err.Error())
-			return nil, errors.DatabaseError(err)
-		}
-
-		if extendInfoJSON != "" {
-			if err := json.Unmarshal([]byte(extendInfoJSON), &item.ExtendInfo); err != nil {
-				r.logger.Error("Repository unmarshal extend_info failed", "id", item.ID, "error", err.Error())
-				return nil, errors.DatabaseError(err)
-			}
-		}
-
-		items = append(items, item)
+	items, err := r.scanItems(rows)
+	if err != nil {
+		return nil, err
 	}
 
 	r.logger.Debug("Repository find by API source success", "api_source", apiSource, "count", len(items))
@@ -199,9 +158,9 @@ func (r *ItemRepository) FindByStatus(ctx context.Context, status string, limit,
 	r.logger.Debug("Repository find by status", "status", status, "limit", limit, "offset", offset)
 
 	query := `
-		SELECT id, title, description, external_id, api_source, extend_info, last_synced_at, created_at, updated_at
+		SELECT id, title, description, external_id, api_source, extend_info, last_synced_at, created_at, updated_at, deleted_at, stale_at
 		FROM items 
-		WHERE JSON_EXTRACT(extend_info, '$.status') = ?
+		WHERE JSON_EXTRACT(extend_info, '$.status') = ? AND deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
 	`
@@ -213,31 +172,40 @@ func (r *ItemRepository) FindByStatus(ctx context.Context, status string, limit,
 	}
 	defer rows.Close()
 
-	var items []entity.Item
-	for rows.Next() {
-		var item entity.Item
-		var extendInfoJSON string
+	items, err := r.scanItems(rows)
+	if err != nil {
+		return nil, err
+	}
 
-		err := rows.Scan(
-			&item.ID, &item.Title, &item.Description, &item.ExternalID, &item.APISource,
-			&extendInfoJSON, &item.SyncedAt, &item.CreatedAt, &item.UpdatedAt,
-		)
-		if err != nil {
-			r.logger.Error("Repository scan item failed", "error", err.Error())
-			return nil, errors.DatabaseError(err)
-		}
+	r.logger.Debug("Repository find by status success", "status", status, "count", len(items))
+	return items, nil
+}
 
-		if extendInfoJSON != "" {
-			if err := json.Unmarshal([]byte(extendInfoJSON), &item.ExtendInfo); err != nil {
-				r.logger.Error("Repository unmarshal extend_info failed", "id", item.ID, "error", err.Error())
-				return nil, errors.DatabaseError(err)
-			}
-		}
+// FindDeleted lists soft-deleted items, an empty apiSource matches every source
+func (r *ItemRepository) FindDeleted(ctx context.Context, apiSource string, limit, offset int) ([]entity.Item, error) {
+	r.logger.Debug("Repository find deleted", "api_source", apiSource, "limit", limit, "offset", offset)
 
-		items = append(items, item)
+	query := `
+		SELECT id, title, description, external_id, api_source, extend_info, last_synced_at, created_at, updated_at, deleted_at, stale_at
+		FROM items 
+		WHERE deleted_at IS NOT NULL AND (? = '' OR api_source = ?)
+		ORDER BY deleted_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, apiSource, apiSource, limit, offset)
+	if err != nil {
+		r.logger.Error("Repository find deleted failed", "api_source", apiSource, "error", err.Error())
+		return nil, errors.DatabaseError(err)
 	}
+	defer rows.Close()
 
-	r.logger.Debug("Repository find by status success", "status", status, "count", len(items))
+	items, err := r.scanItems(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.Debug("Repository find deleted success", "api_source", apiSource, "count", len(items))
 	return items, nil
 }
 
@@ -246,20 +214,159 @@ func (r *ItemRepository) FindByType(ctx context.Context, itemType string, limit,
 	return r.FindByAPISource(ctx, itemType, limit, offset)
 }
 
-func (r *ItemRepository) UpsertWithHash(ctx context.Context, apiSource string, externalItem entity.ExternalItem) error {
-	now := time.Now()
+// UpsertWithHash stores a single item and reports whether it was inserted, updated or left unchanged
+func (r *ItemRepository) UpsertWithHash(ctx context.Context, apiSource string, externalItem entity.ExternalItem) (entity.UpsertOutcome, error) {
+	results, err := r.UpsertManyWithHash(ctx, apiSource, []entity.ExternalItem{externalItem})
+	if err != nil {
+		r.logger.Error("Repository upsert with hash failed", "external_id", externalItem.ID, "api_source", apiSource, "error", err.Error())
+		return entity.UpsertFailed, err
+	}
+
+	r.logger.Debug("Repository upsert with hash success", "external_id", externalItem.ID, "api_source", apiSource, "outcome", results[0].Outcome)
+	return results[0].Outcome, nil
+}
+
+type upsertRow struct {
+	index          int
+	item           entity.ExternalItem
+	extendInfoJSON string
+	contentHash    string
+}
+
+// UpsertManyWithHash upserts the items in multi-row statements of at most batchSize rows, each batch in its own
+// transaction. Results are returned in input order; when a batch fails all of its items are marked as failed
+// and the last batch error is returned alongside the results.
+func (r *ItemRepository) UpsertManyWithHash(ctx context.Context, apiSource string, externalItems []entity.ExternalItem) ([]entity.UpsertResult, error) {
+	results := make([]entity.UpsertResult, len(externalItems))
+
+	var lastErr error
+	var batch []upsertRow
+	seen := make(map[int]bool)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.upsertBatch(ctx, apiSource, batch, results); err != nil {
+			lastErr = err
+		}
+		batch = batch[:0]
+		clear(seen)
+	}
 
-	extendInfoJSON, err := json.Marshal(externalItem.ExtendInfo)
+	for i, externalItem := range externalItems {
+		results[i].ExternalID = externalItem.ID
+
+		extendInfoJSON, err := json.Marshal(externalItem.ExtendInfo)
+		if err != nil {
+			r.logger.Error("Repository marshal extend_info failed", "external_id", externalItem.ID, "error", err.Error())
+			results[i].Outcome = entity.UpsertFailed
+			results[i].Err = errors.DatabaseError(err)
+			lastErr = results[i].Err
+			continue
+		}
+
+		// A repeated ID must not share a statement with its previous occurrence, otherwise both would be classified
+		// against the same pre-existing row
+		if seen[externalItem.ID] || len(batch) == r.batchSize {
+			flush()
+		}
+
+		seen[externalItem.ID] = true
+		batch = append(batch, upsertRow{
+			index:          i,
+			item:           externalItem,
+			extendInfoJSON: string(extendInfoJSON),
+			contentHash:    r.calculateContentHash(externalItem.Title, string(extendInfoJSON)),
+		})
+	}
+	flush()
+
+	return results, lastErr
+}
+
+func (r *ItemRepository) upsertBatch(ctx context.Context, apiSource string, batch []upsertRow, results []entity.UpsertResult) error {
+	err := r.execUpsertBatch(ctx, apiSource, batch, results)
 	if err != nil {
-		r.logger.Error("Repository marshal extend_info failed", "external_id", externalItem.ID, "error", err.Error())
-		return errors.DatabaseError(err)
+		r.logger.Error("Repository batch upsert failed", "api_source", apiSource, "batch_size", len(batch), "error", err.Error())
+		domainErr := errors.DatabaseError(err)
+		for _, row := range batch {
+			results[row.index].Outcome = entity.UpsertFailed
+			results[row.index].Err = domainErr
+		}
+		return domainErr
 	}
 
-	contentHash := r.calculateContentHash(externalItem.Title, string(extendInfoJSON))
+	r.logger.Debug("Repository batch upsert success", "api_source", apiSource, "batch_size", len(batch))
+	return nil
+}
 
-	query := `
+func (r *ItemRepository) execUpsertBatch(ctx context.Context, apiSource string, batch []upsertRow, results []entity.UpsertResult) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the existing rows so the classification matches what the upsert below does
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",")
+	args := make([]interface{}, 0, len(batch)+1)
+	args = append(args, apiSource)
+	for _, row := range batch {
+		args = append(args, row.item.ID)
+	}
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, external_id, content_hash, title, extend_info
+		FROM items
+		WHERE api_source = ? AND external_id IN (%s)
+		FOR UPDATE
+	`, placeholders), args...)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[int]storedItem, len(batch))
+	for rows.Next() {
+		var stored storedItem
+		var externalID int
+		var contentHash, extendInfoJSON sql.NullString
+		if err := rows.Scan(&stored.id, &externalID, &contentHash, &stored.title, &extendInfoJSON); err != nil {
+			rows.Close()
+			return err
+		}
+		stored.contentHash = contentHash.String
+		stored.extendInfoJSON = extendInfoJSON.String
+		existing[externalID] = stored
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	values := make([]string, 0, len(batch))
+	args = make([]interface{}, 0, len(batch)*9)
+	for _, row := range batch {
+		values = append(values, "(?, ?, ?, ?, ?, ?, ?, ?, ?, 1)")
+		args = append(args,
+			row.item.Title,
+			row.item.Description,
+			row.item.ID,
+			apiSource,
+			row.extendInfoJSON,
+			row.contentHash,
+			now,
+			now,
+			now,
+		)
+	}
+
+	query := fmt.Sprintf(`
 		INSERT INTO items (title, description, external_id, api_source, extend_info, content_hash, last_synced_at, created_at, updated_at, sync_attempts)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1)
+		VALUES %s
 		ON DUPLICATE KEY UPDATE
 			title = VALUES(title),
 			description = VALUES(description),
@@ -271,30 +378,259 @@ func (r *ItemRepository) UpsertWithHash(ctx context.Context, apiSource string, e
 			last_synced_at = VALUES(last_synced_at),
 			updated_at = VALUES(updated_at),
 			sync_attempts = sync_attempts + 1,
-			last_sync_error = NULL
-	`
+			last_sync_error = NULL,
+			deleted_at = NULL,
+			stale_at = NULL
+	`, strings.Join(values, ", "))
 
-	_, err = r.db.ExecContext(ctx, query,
-		externalItem.Title,
-		"", // description - might be extracted from extend_info if needed
-		externalItem.ID,
-		apiSource,
-		string(extendInfoJSON),
-		contentHash,
-		now,
-		now,
-		now,
-	)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
 
-	if err != nil {
-		r.logger.Error("Repository upsert with hash failed", "external_id", externalItem.ID, "api_source", apiSource, "error", err.Error())
-		return errors.DatabaseError(err)
+	if err := r.insertVersions(ctx, tx, apiSource, batch, existing); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, row := range batch {
+		stored, exists := existing[row.item.ID]
+		switch {
+		case !exists:
+			results[row.index].Outcome = entity.UpsertInserted
+		case stored.contentHash != row.contentHash:
+			results[row.index].Outcome = entity.UpsertUpdated
+		default:
+			results[row.index].Outcome = entity.UpsertUnchanged
+		}
 	}
 
-	r.logger.Debug("Repository upsert with hash success", "external_id", externalItem.ID, "api_source", apiSource, "content_hash", contentHash)
 	return nil
 }
 
+// storedItem is the state of an item row before a batch upsert touched it
+type storedItem struct {
+	id             int
+	contentHash    string
+	title          string
+	extendInfoJSON string
+}
+
+// insertVersions records an item_versions row for every inserted item and every item whose content hash changed.
+// It runs inside the upsert transaction so a version is only kept when the change itself is committed.
+func (r *ItemRepository) insertVersions(ctx context.Context, tx *sql.Tx, apiSource string, batch []upsertRow, existing map[int]storedItem) error {
+	var inserted []interface{}
+	for _, row := range batch {
+		if _, exists := existing[row.item.ID]; !exists {
+			inserted = append(inserted, row.item.ID)
+		}
+	}
+
+	// New rows have no ID yet in existing, look them up now that the insert is done
+	itemIDs := make(map[int]int, len(batch))
+	for externalID, stored := range existing {
+		itemIDs[externalID] = stored.id
+	}
+	if len(inserted) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(inserted)), ",")
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT id, external_id
+			FROM items
+			WHERE api_source = ? AND external_id IN (%s)
+		`, placeholders), append([]interface{}{apiSource}, inserted...)...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var id, externalID int
+			if err := rows.Scan(&id, &externalID); err != nil {
+				rows.Close()
+				return err
+			}
+			itemIDs[externalID] = id
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	var syncJobID sql.NullInt64
+	if jobID, ok := entity.SyncJobIDFromContext(ctx); ok {
+		syncJobID = sql.NullInt64{Int64: jobID, Valid: true}
+	}
+
+	now := time.Now()
+	var values []string
+	var args []interface{}
+	for _, row := range batch {
+		stored, exists := existing[row.item.ID]
+		if exists && stored.contentHash == row.contentHash {
+			continue
+		}
+
+		newPayload, err := json.Marshal(map[string]interface{}{
+			"title":       row.item.Title,
+			"extend_info": json.RawMessage(row.extendInfoJSON),
+		})
+		if err != nil {
+			return err
+		}
+
+		var oldPayload sql.NullString
+		if exists {
+			extendInfo := json.RawMessage("null")
+			if stored.extendInfoJSON != "" {
+				extendInfo = json.RawMessage(stored.extendInfoJSON)
+			}
+			payload, err := json.Marshal(map[string]interface{}{
+				"title":       stored.title,
+				"extend_info": extendInfo,
+			})
+			if err != nil {
+				return err
+			}
+			oldPayload = sql.NullString{String: string(payload), Valid: true}
+		}
+
+		values = append(values, "(?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, itemIDs[row.item.ID], syncJobID, stored.contentHash, row.contentHash, oldPayload, string(newPayload), now)
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO item_versions (item_id, sync_job_id, previous_hash, content_hash, old_payload, new_payload, created_at)
+		VALUES %s
+	`, strings.Join(values, ", ")), args...)
+	return err
+}
+
+// ReconcileMissingItems applies the deletion policy to the stored items of apiSource whose external ID is not in
+// seenIDs and returns how many items it affected. Items already soft-deleted, or already flagged under
+// DeletionFlagOnly, are left as they are.
+func (r *ItemRepository) ReconcileMissingItems(ctx context.Context, apiSource string, seenIDs []int, policy entity.DeletionPolicy) (int, error) {
+	query := "SELECT id, external_id FROM items WHERE api_source = ?"
+	switch policy {
+	case entity.DeletionSoftDelete:
+		query += " AND deleted_at IS NULL"
+	case entity.DeletionFlagOnly:
+		query += " AND deleted_at IS NULL AND stale_at IS NULL"
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, apiSource)
+	if err != nil {
+		r.logger.Error("Repository load stored items failed", "api_source", apiSource, "error", err.Error())
+		return 0, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	seen := make(map[int]bool, len(seenIDs))
+	for _, id := range seenIDs {
+		seen[id] = true
+	}
+
+	var missing []interface{}
+	for rows.Next() {
+		var id, externalID int
+		if err := rows.Scan(&id, &externalID); err != nil {
+			r.logger.Error("Repository scan stored item failed", "api_source", apiSource, "error", err.Error())
+			return 0, errors.DatabaseError(err)
+		}
+		if !seen[externalID] {
+			missing = append(missing, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Repository iterate stored items failed", "api_source", apiSource, "error", err.Error())
+		return 0, errors.DatabaseError(err)
+	}
+
+	now := time.Now()
+	affected := 0
+	for batch := range slices.Chunk(missing, r.batchSize) {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",")
+
+		var statement string
+		var args []interface{}
+		switch policy {
+		case entity.DeletionHardDelete:
+			statement = fmt.Sprintf("DELETE FROM items WHERE id IN (%s)", placeholders)
+		case entity.DeletionSoftDelete:
+			statement = fmt.Sprintf("UPDATE items SET deleted_at = ?, stale_at = COALESCE(stale_at, ?) WHERE id IN (%s)", placeholders)
+			args = append(args, now, now)
+		default:
+			statement = fmt.Sprintf("UPDATE items SET stale_at = ? WHERE id IN (%s)", placeholders)
+			args = append(args, now)
+		}
+		args = append(args, batch...)
+
+		result, err := r.db.ExecContext(ctx, statement, args...)
+		if err != nil {
+			r.logger.Error("Repository reconcile missing items failed", "api_source", apiSource, "policy", policy, "error", err.Error())
+			return affected, errors.DatabaseError(err)
+		}
+		count, _ := result.RowsAffected()
+		affected += int(count)
+	}
+
+	r.logger.Debug("Repository reconcile missing items success", "api_source", apiSource, "policy", policy, "affected", affected)
+	return affected, nil
+}
+
+func (r *ItemRepository) scanItems(rows *sql.Rows) ([]entity.Item, error) {
+	var items []entity.Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			r.logger.Error("Repository scan item failed", "error", err.Error())
+			return nil, errors.DatabaseError(err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Repository iterate items failed", "error", err.Error())
+		return nil, errors.DatabaseError(err)
+	}
+
+	return items, nil
+}
+
+func scanItem(row rowScanner) (entity.Item, error) {
+	var item entity.Item
+	var extendInfoJSON sql.NullString
+	var deletedAt, staleAt sql.NullTime
+
+	err := row.Scan(
+		&item.ID, &item.Title, &item.Description, &item.ExternalID, &item.APISource,
+		&extendInfoJSON, &item.SyncedAt, &item.CreatedAt, &item.UpdatedAt, &deletedAt, &staleAt,
+	)
+	if err != nil {
+		return entity.Item{}, err
+	}
+
+	if extendInfoJSON.String != "" {
+		if err := json.Unmarshal([]byte(extendInfoJSON.String), &item.ExtendInfo); err != nil {
+			return entity.Item{}, err
+		}
+	}
+	if deletedAt.Valid {
+		item.DeletedAt = &deletedAt.Time
+	}
+	if staleAt.Valid {
+		item.StaleAt = &staleAt.Time
+	}
+
+	return item, nil
+}
+
 func (r *ItemRepository) calculateContentHash(title string, extendInfoJSON string) string {
 	content := fmt.Sprintf("%s:%s", title, extendInfoJSON)
 	hash := sha256.Sum256([]byte(content))
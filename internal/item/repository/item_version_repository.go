@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/internal/item/usecase"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+// Ensure the repository implements the required interfaces
+var _ usecase.ItemVersionRepository = (*ItemVersionRepository)(nil)
+
+// ItemVersionRepository reads the item_versions rows written by ItemRepository upserts
+type ItemVersionRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewItemVersionRepository(db *sql.DB, logger logger.Logger) *ItemVersionRepository {
+	return &ItemVersionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ItemVersionRepository) ListItemVersions(ctx context.Context, itemID int, limit, offset int) ([]entity.ItemVersion, error) {
+	r.logger.Debug("Repository list item versions", "item_id", itemID, "limit", limit, "offset", offset)
+
+	query := `
+		SELECT id, item_id, sync_job_id, previous_hash, content_hash, old_payload, new_payload, created_at
+		FROM item_versions
+		WHERE item_id = ?
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, itemID, limit, offset)
+	if err != nil {
+		r.logger.Error("Repository list item versions failed", "item_id", itemID, "error", err.Error())
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var versions []entity.ItemVersion
+	for rows.Next() {
+		version, err := scanItemVersion(rows)
+		if err != nil {
+			r.logger.Error("Repository scan item version failed", "item_id", itemID, "error", err.Error())
+			return nil, errors.DatabaseError(err)
+		}
+		versions = append(versions, version)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Repository iterate item versions failed", "item_id", itemID, "error", err.Error())
+		return nil, errors.DatabaseError(err)
+	}
+
+	r.logger.Debug("Repository list item versions success", "item_id", itemID, "count", len(versions))
+	return versions, nil
+}
+
+func (r *ItemVersionRepository) CountItemVersions(ctx context.Context, itemID int) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM item_versions WHERE item_id = ?", itemID).Scan(&count)
+	if err != nil {
+		r.logger.Error("Repository count item versions failed", "item_id", itemID, "error", err.Error())
+		return 0, errors.DatabaseError(err)
+	}
+
+	return count, nil
+}
+
+func (r *ItemVersionRepository) FindItemVersion(ctx context.Context, itemID int, versionID int64) (entity.ItemVersion, error) {
+	r.logger.Debug("Repository find item version", "item_id", itemID, "version_id", versionID)
+
+	query := `
+		SELECT id, item_id, sync_job_id, previous_hash, content_hash, old_payload, new_payload, created_at
+		FROM item_versions
+		WHERE id = ? AND item_id = ?
+	`
+
+	version, err := scanItemVersion(r.db.QueryRowContext(ctx, query, versionID, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			r.logger.Debug("Repository item version not found", "item_id", itemID, "version_id", versionID)
+			return entity.ItemVersion{}, errors.ItemVersionNotFound(versionID)
+		}
+		r.logger.Error("Repository find item version failed", "item_id", itemID, "version_id", versionID, "error", err.Error())
+		return entity.ItemVersion{}, errors.DatabaseError(err)
+	}
+
+	return version, nil
+}
+
+func scanItemVersion(row rowScanner) (entity.ItemVersion, error) {
+	var version entity.ItemVersion
+	var syncJobID sql.NullInt64
+	var oldPayload sql.NullString
+	var newPayload string
+
+	err := row.Scan(
+		&version.ID, &version.ItemID, &syncJobID, &version.PreviousHash, &version.ContentHash,
+		&oldPayload, &newPayload, &version.CreatedAt,
+	)
+	if err != nil {
+		return entity.ItemVersion{}, err
+	}
+
+	if syncJobID.Valid {
+		version.SyncJobID = &syncJobID.Int64
+	}
+	if oldPayload.Valid {
+		var snapshot entity.ItemSnapshot
+		if err := json.Unmarshal([]byte(oldPayload.String), &snapshot); err != nil {
+			return entity.ItemVersion{}, err
+		}
+		version.OldPayload = &snapshot
+	}
+	if err := json.Unmarshal([]byte(newPayload), &version.NewPayload); err != nil {
+		return entity.ItemVersion{}, err
+	}
+
+	return version, nil
+}
@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
+	"github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
 	"github.com/zainokta/item-sync/pkg/logger"
 )
 
@@ -34,7 +37,7 @@ func (j *JobRepository) CreateSyncJobRecord(ctx context.Context, name string, ap
 	return result.LastInsertId()
 }
 
-func (j *JobRepository) UpdateSyncJobRecord(ctx context.Context, jobID int64, status string, processed, succeeded, failed int, lastErr error, executionTime time.Duration) error {
+func (j *JobRepository) UpdateSyncJobRecord(ctx context.Context, jobID int64, status string, stats entity.SyncJobStats, lastErr error, executionTime time.Duration) error {
 	var errorMessage sql.NullString
 	if lastErr != nil {
 		errorMessage = sql.NullString{String: lastErr.Error(), Valid: true}
@@ -43,13 +46,222 @@ func (j *JobRepository) UpdateSyncJobRecord(ctx context.Context, jobID int64, st
 	query := `
 		UPDATE sync_jobs 
 		SET status = ?, completed_at = ?, items_processed = ?, items_succeeded = ?, 
-		    items_failed = ?, error_message = ?, execution_time_ms = ?
+		    items_failed = ?, items_inserted = ?, items_updated = ?, items_unchanged = ?,
+		    pages_not_modified = ?, error_message = ?, execution_time_ms = ?
 		WHERE id = ?
 	`
 
 	_, err := j.db.ExecContext(ctx, query,
-		status, time.Now(), processed, succeeded, failed,
-		errorMessage, executionTime.Milliseconds(), jobID)
+		status, time.Now(), stats.Processed, stats.Succeeded, stats.Failed,
+		stats.Inserted, stats.Updated, stats.Unchanged,
+		stats.PagesNotModified, errorMessage, executionTime.Milliseconds(), jobID)
 
 	return err
 }
+
+func (j *JobRepository) FindSyncJobByID(ctx context.Context, jobID int64) (entity.SyncJobRecord, error) {
+	j.logger.Debug("Repository find sync job by ID", "job_id", jobID)
+
+	query := `
+		SELECT id, job_name, api_source, status, started_at, completed_at, items_processed,
+		       items_succeeded, items_failed, items_inserted, items_updated, items_unchanged,
+		       pages_not_modified, error_message, execution_time_ms
+		FROM sync_jobs
+		WHERE id = ?
+	`
+
+	job, err := scanSyncJob(j.db.QueryRowContext(ctx, query, jobID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			j.logger.Debug("Repository sync job not found", "job_id", jobID)
+			return entity.SyncJobRecord{}, errors.SyncJobNotFound()
+		}
+		j.logger.Error("Repository find sync job by ID failed", "job_id", jobID, "error", err.Error())
+		return entity.SyncJobRecord{}, errors.DatabaseError(err)
+	}
+
+	return job, nil
+}
+
+func (j *JobRepository) ListSyncJobs(ctx context.Context, filter entity.SyncJobFilter) ([]entity.SyncJobRecord, error) {
+	j.logger.Debug("Repository list sync jobs", "api_source", filter.APISource, "status", filter.Status, "limit", filter.Limit, "offset", filter.Offset)
+
+	where, args := syncJobConditions(filter)
+
+	query := `
+		SELECT id, job_name, api_source, status, started_at, completed_at, items_processed,
+		       items_succeeded, items_failed, items_inserted, items_updated, items_unchanged,
+		       pages_not_modified, error_message, execution_time_ms
+		FROM sync_jobs
+	`
+	query += where
+	query += " ORDER BY started_at DESC, id DESC LIMIT ? OFFSET ?"
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := j.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		j.logger.Error("Repository list sync jobs failed", "error", err.Error())
+		return nil, errors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	var jobs []entity.SyncJobRecord
+	for rows.Next() {
+		job, err := scanSyncJob(rows)
+		if err != nil {
+			j.logger.Error("Repository scan sync job failed", "error", err.Error())
+			return nil, errors.DatabaseError(err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		j.logger.Error("Repository iterate sync jobs failed", "error", err.Error())
+		return nil, errors.DatabaseError(err)
+	}
+
+	j.logger.Debug("Repository list sync jobs success", "count", len(jobs))
+	return jobs, nil
+}
+
+func (j *JobRepository) CountSyncJobs(ctx context.Context, filter entity.SyncJobFilter) (int, error) {
+	where, args := syncJobConditions(filter)
+
+	var count int
+	err := j.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sync_jobs"+where, args...).Scan(&count)
+	if err != nil {
+		j.logger.Error("Repository count sync jobs failed", "error", err.Error())
+		return 0, errors.DatabaseError(err)
+	}
+
+	return count, nil
+}
+
+// syncJobConditions builds the WHERE clause shared by ListSyncJobs and CountSyncJobs
+func syncJobConditions(filter entity.SyncJobFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.APISource != "" {
+		conditions = append(conditions, "api_source = ?")
+		args = append(args, filter.APISource)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if !filter.StartedAfter.IsZero() {
+		conditions = append(conditions, "started_at >= ?")
+		args = append(args, filter.StartedAfter)
+	}
+	if !filter.StartedBefore.IsZero() {
+		conditions = append(conditions, "started_at <= ?")
+		args = append(args, filter.StartedBefore)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// FindSyncCheckpoint returns the checkpoint of an unfinished pass, or nil when the next pass starts from the beginning
+func (j *JobRepository) FindSyncCheckpoint(ctx context.Context, apiSource string) (*entity.SyncCheckpoint, error) {
+	query := `
+		SELECT api_source, cursor_offset, next_url, cursor, watermark, sync_job_id, updated_at
+		FROM sync_checkpoints
+		WHERE api_source = ?
+	`
+
+	var checkpoint entity.SyncCheckpoint
+	var nextURL sql.NullString
+	var watermark sql.NullTime
+	var syncJobID sql.NullInt64
+
+	err := j.db.QueryRowContext(ctx, query, apiSource).Scan(
+		&checkpoint.APISource, &checkpoint.Offset, &nextURL, &checkpoint.Cursor, &watermark, &syncJobID, &checkpoint.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		j.logger.Error("Repository find sync checkpoint failed", "api_source", apiSource, "error", err.Error())
+		return nil, errors.DatabaseError(err)
+	}
+
+	checkpoint.NextURL = nextURL.String
+	checkpoint.SyncJobID = syncJobID.Int64
+	if watermark.Valid {
+		checkpoint.Watermark = &watermark.Time
+	}
+
+	return &checkpoint, nil
+}
+
+func (j *JobRepository) SaveSyncCheckpoint(ctx context.Context, checkpoint entity.SyncCheckpoint) error {
+	query := `
+		INSERT INTO sync_checkpoints (api_source, cursor_offset, next_url, cursor, watermark, sync_job_id, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			cursor_offset = VALUES(cursor_offset),
+			next_url = VALUES(next_url),
+			cursor = VALUES(cursor),
+			watermark = VALUES(watermark),
+			sync_job_id = VALUES(sync_job_id),
+			updated_at = VALUES(updated_at)
+	`
+
+	var watermark sql.NullTime
+	if checkpoint.Watermark != nil {
+		watermark = sql.NullTime{Time: *checkpoint.Watermark, Valid: true}
+	}
+	var syncJobID sql.NullInt64
+	if checkpoint.SyncJobID != 0 {
+		syncJobID = sql.NullInt64{Int64: checkpoint.SyncJobID, Valid: true}
+	}
+
+	_, err := j.db.ExecContext(ctx, query,
+		checkpoint.APISource, checkpoint.Offset, checkpoint.NextURL, checkpoint.Cursor, watermark, syncJobID, time.Now())
+	if err != nil {
+		j.logger.Error("Repository save sync checkpoint failed", "api_source", checkpoint.APISource, "error", err.Error())
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+func (j *JobRepository) DeleteSyncCheckpoint(ctx context.Context, apiSource string) error {
+	if _, err := j.db.ExecContext(ctx, "DELETE FROM sync_checkpoints WHERE api_source = ?", apiSource); err != nil {
+		j.logger.Error("Repository delete sync checkpoint failed", "api_source", apiSource, "error", err.Error())
+		return errors.DatabaseError(err)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSyncJob(row rowScanner) (entity.SyncJobRecord, error) {
+	var job entity.SyncJobRecord
+	var completedAt sql.NullTime
+	var errorMessage sql.NullString
+
+	err := row.Scan(
+		&job.ID, &job.JobName, &job.APISource, &job.Status, &job.StartedAt, &completedAt,
+		&job.ItemsProcessed, &job.ItemsSucceeded, &job.ItemsFailed,
+		&job.ItemsInserted, &job.ItemsUpdated, &job.ItemsUnchanged, &job.PagesNotModified,
+		&errorMessage, &job.ExecutionTimeMs,
+	)
+	if err != nil {
+		return entity.SyncJobRecord{}, err
+	}
+
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+	job.ErrorMessage = errorMessage.String
+
+	return job, nil
+}
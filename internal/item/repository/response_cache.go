@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+// Ensure the cache implements the required interface
+var _ api.ResponseCache = (*ResponseCache)(nil)
+
+// responseCacheKeyPrefix namespaces the cached upstream responses, keys end with the SHA-256 of the URL so that
+// credentials in query strings are not stored as key names
+const responseCacheKeyPrefix = "upstream:response:"
+
+// ResponseCache keeps the ETag, Last-Modified and body of upstream listing pages in Redis
+type ResponseCache struct {
+	client *redis.Client
+	logger logger.Logger
+}
+
+func NewResponseCache(client *redis.Client, logger logger.Logger) *ResponseCache {
+	return &ResponseCache{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (c *ResponseCache) GetResponse(ctx context.Context, url string) (*api.CachedResponse, error) {
+	key := responseCacheKey(url)
+
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		c.logger.Error("Cache get response failed", "key", key, "error", err.Error())
+		return nil, errors.CacheFailed(err)
+	}
+
+	var response api.CachedResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		c.logger.Error("Cache unmarshal response failed", "key", key, "error", err.Error())
+		return nil, errors.CacheFailed(err)
+	}
+
+	return &response, nil
+}
+
+func (c *ResponseCache) SetResponse(ctx context.Context, url string, response api.CachedResponse, ttl time.Duration) error {
+	key := responseCacheKey(url)
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		c.logger.Error("Cache marshal response failed", "key", key, "error", err.Error())
+		return errors.CacheFailed(err)
+	}
+
+	if err := c.client.Set(ctx, key, data, ttl).Err(); err != nil {
+		c.logger.Error("Cache set response failed", "key", key, "error", err.Error())
+		return errors.CacheFailed(err)
+	}
+
+	c.logger.Debug("Cache set response success", "key", key, "etag", response.ETag, "last_modified", response.LastModified)
+	return nil
+}
+
+func responseCacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return responseCacheKeyPrefix + hex.EncodeToString(sum[:])
+}
@@ -2,6 +2,9 @@ package strategy
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/zainokta/item-sync/internal/item/entity"
 )
@@ -16,10 +19,67 @@ func NewOpenWeatherSyncStrategy(apiClient ExternalAPIClient) *OpenWeatherSyncStr
 	}
 }
 
+// FetchAllItems fetches the weather of every city in the "cities" param, a failed city does not stop the others
 func (o *OpenWeatherSyncStrategy) FetchAllItems(ctx context.Context, request SyncItemsRequest) ([]entity.ExternalItem, error) {
-	return o.apiClient.Fetch(ctx, request.APISource, request.Operation, request.Params)
+	return collectAllItems(ctx, o, request)
+}
+
+// StreamAllItems hands over the items of one city at a time
+func (o *OpenWeatherSyncStrategy) StreamAllItems(ctx context.Context, request SyncItemsRequest, handle PageHandler) error {
+	cities := o.parseCities(request.Params)
+	if len(cities) == 0 {
+		items, err := o.Fetch(ctx, request)
+		if err != nil {
+			return err
+		}
+		return handle(ctx, Page{Items: items, Last: true})
+	}
+
+	var errs []error
+
+	for i, city := range cities {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		// Merge request params with city-specific params
+		params := make(map[string]interface{}, len(request.Params)+1)
+		for k, v := range request.Params {
+			params[k] = v
+		}
+		params["city"] = city
+
+		items, err := o.apiClient.Fetch(ctx, request.APISource, request.Operation, params)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("city %s: %w", city, err))
+			continue
+		}
+
+		if err := handle(ctx, Page{Items: items, Last: i == len(cities)-1}); err != nil {
+			errs = append(errs, err)
+			break
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func (o *OpenWeatherSyncStrategy) Fetch(ctx context.Context, request SyncItemsRequest) ([]entity.ExternalItem, error) {
 	return o.apiClient.Fetch(ctx, request.APISource, request.Operation, request.Params)
 }
+
+func (o *OpenWeatherSyncStrategy) parseCities(params map[string]interface{}) []string {
+	citiesParam, ok := params["cities"].(string)
+	if !ok {
+		return nil
+	}
+
+	var cities []string
+	for _, city := range strings.Split(citiesParam, ",") {
+		if city = strings.TrimSpace(city); city != "" {
+			cities = append(cities, city)
+		}
+	}
+	return cities
+}
@@ -0,0 +1,57 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/api"
+)
+
+type mockWeatherAPIClient struct {
+	failCities map[string]bool
+	cities     []string
+}
+
+func (m *mockWeatherAPIClient) Fetch(ctx context.Context, apiName string, operation string, params map[string]interface{}) ([]entity.ExternalItem, error) {
+	city, _ := params["city"].(string)
+	m.cities = append(m.cities, city)
+
+	if m.failCities[city] {
+		return nil, errors.New("city not found")
+	}
+
+	return []entity.ExternalItem{{ID: len(m.cities), Title: city}}, nil
+}
+
+func (m *mockWeatherAPIClient) FetchPaginated(ctx context.Context, apiName string, operation string, params map[string]interface{}) (*api.PaginatedResponse, error) {
+	return nil, nil
+}
+
+func (m *mockWeatherAPIClient) FetchByID(ctx context.Context, apiName string, id int) (entity.ExternalItem, error) {
+	return entity.ExternalItem{}, nil
+}
+
+func TestOpenWeatherSyncStrategy_FetchAllItems(t *testing.T) {
+	mockClient := &mockWeatherAPIClient{failCities: map[string]bool{"Atlantis": true}}
+
+	strategy := NewOpenWeatherSyncStrategy(mockClient)
+	request := SyncItemsRequest{
+		APISource: "openweather",
+		Operation: "weather",
+		Params: map[string]interface{}{
+			"cities": "Jakarta, Atlantis,Bandung",
+		},
+	}
+
+	items, err := strategy.FetchAllItems(context.Background(), request)
+
+	assert.Error(t, err, "Failed city should be reported")
+	assert.Contains(t, err.Error(), "Atlantis")
+	assert.Equal(t, []string{"Jakarta", "Atlantis", "Bandung"}, mockClient.cities, "Every city should be fetched")
+	assert.Len(t, items, 2, "Items of the other cities should still be returned")
+	assert.Equal(t, "Jakarta", items[0].Title)
+	assert.Equal(t, "Bandung", items[1].Title)
+}
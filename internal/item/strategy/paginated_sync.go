@@ -0,0 +1,83 @@
+package strategy
+
+import (
+	"context"
+
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+// PaginatedSyncStrategy follows the opaque pagination token returned by clients such as api.RESTClient
+type PaginatedSyncStrategy struct {
+	logger    logger.Logger
+	apiClient ExternalAPIClient
+	maxPages  int
+}
+
+func NewPaginatedSyncStrategy(logger logger.Logger, apiClient ExternalAPIClient, maxPages int) *PaginatedSyncStrategy {
+	return &PaginatedSyncStrategy{
+		logger:    logger,
+		apiClient: apiClient,
+		maxPages:  maxPages,
+	}
+}
+
+func (p *PaginatedSyncStrategy) FetchAllItems(ctx context.Context, request SyncItemsRequest) ([]entity.ExternalItem, error) {
+	return collectAllItems(ctx, p, request)
+}
+
+func (p *PaginatedSyncStrategy) StreamAllItems(ctx context.Context, request SyncItemsRequest, handle PageHandler) error {
+	params := make(map[string]interface{}, len(request.Params)+1)
+	for k, v := range request.Params {
+		params[k] = v
+	}
+	if request.Checkpoint != nil && request.Checkpoint.Cursor != "" {
+		params[api.PageTokenParam] = request.Checkpoint.Cursor
+	}
+
+	for page := 1; ; page++ {
+		response, err := p.apiClient.FetchPaginated(ctx, request.APISource, request.Operation, params)
+		if err != nil {
+			return err
+		}
+
+		hasNext := response.Pagination != nil && response.Pagination.HasNext
+		// A repeated token would fetch the same page forever
+		if hasNext && response.Pagination.Next == params[api.PageTokenParam] {
+			hasNext = false
+		}
+		if hasNext && request.Checkpoint != nil {
+			request.Checkpoint.Cursor = response.Pagination.Next
+		}
+
+		if err := handle(ctx, Page{Items: response.Items, NotModified: response.NotModified, Validators: response.Validators, LastModified: response.Validators.LastModified(), Last: !hasNext}); err != nil {
+			return err
+		}
+
+		if !hasNext {
+			break
+		}
+
+		// Safety check to prevent infinite loops on misbehaving upstream pagination
+		if p.maxPages > 0 && page >= p.maxPages {
+			if p.logger != nil {
+				p.logger.Warn("Reached max pages, stopping pagination", "api_source", request.APISource, "max_pages", p.maxPages)
+			}
+			break
+		}
+
+		params[api.PageTokenParam] = response.Pagination.Next
+	}
+
+	return nil
+}
+
+func (p *PaginatedSyncStrategy) Fetch(ctx context.Context, request SyncItemsRequest) ([]entity.ExternalItem, error) {
+	response, err := p.apiClient.FetchPaginated(ctx, request.APISource, request.Operation, request.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
@@ -0,0 +1,73 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/api"
+)
+
+// tokenAPIClient serves pages of one item each, pages is the number of pages before the listing ends
+type tokenAPIClient struct {
+	pages int
+}
+
+func (m *tokenAPIClient) Fetch(ctx context.Context, apiName string, operation string, params map[string]interface{}) ([]entity.ExternalItem, error) {
+	return nil, nil
+}
+
+func (m *tokenAPIClient) FetchPaginated(ctx context.Context, apiName string, operation string, params map[string]interface{}) (*api.PaginatedResponse, error) {
+	page := 0
+	if token, ok := params[api.PageTokenParam].(string); ok {
+		fmt.Sscanf(token, "page-%d", &page)
+	}
+
+	next := ""
+	if page+1 < m.pages {
+		next = fmt.Sprintf("page-%d", page+1)
+	}
+	return &api.PaginatedResponse{
+		Items:      []entity.ExternalItem{{ID: page + 1}},
+		Pagination: api.NewPaginationMetadata(m.pages, next, ""),
+	}, nil
+}
+
+func (m *tokenAPIClient) FetchByID(ctx context.Context, apiName string, id int) (entity.ExternalItem, error) {
+	return entity.ExternalItem{}, nil
+}
+
+func TestPaginatedSyncStrategy_StreamAllItems_MarksLastPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		pages    int
+		maxPages int
+		handled  int
+		last     bool
+	}{
+		{name: "end of listing", pages: 3, maxPages: 10, handled: 3, last: true},
+		{name: "max pages reached", pages: 5, maxPages: 2, handled: 2, last: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewPaginatedSyncStrategy(nil, &tokenAPIClient{pages: tt.pages}, tt.maxPages)
+
+			var pages []Page
+			err := strategy.StreamAllItems(context.Background(), SyncItemsRequest{APISource: "custom"}, func(ctx context.Context, page Page) error {
+				pages = append(pages, page)
+				return nil
+			})
+
+			require.NoError(t, err)
+			require.Len(t, pages, tt.handled)
+			assert.Equal(t, tt.last, pages[len(pages)-1].Last, "Only the page that ends the listing should be marked last")
+			for _, page := range pages[:len(pages)-1] {
+				assert.False(t, page.Last)
+			}
+		})
+	}
+}
@@ -22,8 +22,10 @@ func NewPokemonSyncStrategy(logger logger.Logger, apiClient ExternalAPIClient) *
 }
 
 func (p *PokemonSyncStrategy) FetchAllItems(ctx context.Context, request SyncItemsRequest) ([]entity.ExternalItem, error) {
-	var allItems []entity.ExternalItem
+	return collectAllItems(ctx, p, request)
+}
 
+func (p *PokemonSyncStrategy) StreamAllItems(ctx context.Context, request SyncItemsRequest, handle PageHandler) error {
 	offset := 0
 	limit := 20
 
@@ -36,6 +38,10 @@ func (p *PokemonSyncStrategy) FetchAllItems(ctx context.Context, request SyncIte
 		}
 	}
 
+	if request.Checkpoint != nil && request.Checkpoint.Offset > 0 {
+		offset = request.Checkpoint.Offset
+	}
+
 	for {
 		params := map[string]interface{}{
 			"offset": offset,
@@ -44,43 +50,61 @@ func (p *PokemonSyncStrategy) FetchAllItems(ctx context.Context, request SyncIte
 
 		response, err := p.apiClient.FetchPaginated(ctx, request.APISource, request.Operation, params)
 		if err != nil {
-			return allItems, err
+			return err
 		}
 
+		// The listing ran out exactly at the end of the previous page
 		if len(response.Items) == 0 {
-			break
+			return handle(ctx, Page{Last: true})
 		}
 
-		allItems = append(allItems, response.Items...)
-
-		// Use structured pagination metadata
-		if response.Pagination == nil || !response.Pagination.HasNext {
-			break
+		// A page that was not modified is not stored again, enriching it would be wasted requests
+		items := response.Items
+		if !response.NotModified {
+			items, err = p.enrich(ctx, response.Items)
+			if err != nil {
+				return err
+			}
 		}
 
-		// Parse next URL to get new offset and limit
-		if response.Pagination.Next != "" {
-			newOffset, newLimit, err := p.parseNextURL(response.Pagination.Next)
-			if err != nil {
-				if p.logger != nil {
-					p.logger.Warn("Failed to parse next URL, falling back to increment", "url", response.Pagination.Next, "error", err)
+		// Use structured pagination metadata
+		hasNext := response.Pagination != nil && response.Pagination.HasNext
+		if hasNext {
+			// Parse next URL to get new offset and limit
+			if response.Pagination.Next != "" {
+				newOffset, newLimit, err := p.parseNextURL(response.Pagination.Next)
+				if err != nil {
+					if p.logger != nil {
+						p.logger.Warn("Failed to parse next URL, falling back to increment", "url", response.Pagination.Next, "error", err)
+					}
+					offset += limit
+				} else {
+					offset = newOffset
+					limit = newLimit
 				}
-				offset += limit
 			} else {
-				offset = newOffset
-				limit = newLimit
+				offset += limit
+			}
+
+			if request.Checkpoint != nil {
+				request.Checkpoint.Offset = offset
+				request.Checkpoint.NextURL = response.Pagination.Next
 			}
-		} else {
-			offset += limit
 		}
 
 		// Safety check to prevent infinite loops
-		if len(response.Items) < limit {
+		last := !hasNext || len(response.Items) < limit
+
+		if err := handle(ctx, Page{Items: items, NotModified: response.NotModified, Validators: response.Validators, LastModified: response.Validators.LastModified(), Last: last}); err != nil {
+			return err
+		}
+
+		if last {
 			break
 		}
 	}
 
-	return allItems, nil
+	return nil
 }
 
 func (p *PokemonSyncStrategy) parseNextURL(nextURL string) (offset, limit int, err error) {
@@ -136,5 +160,14 @@ func (p *PokemonSyncStrategy) Fetch(ctx context.Context, request SyncItemsReques
 		return nil, err
 	}
 
-	return response.Items, nil
+	return p.enrich(ctx, response.Items)
+}
+
+// enrich runs the optional detail stage when the client supports it
+func (p *PokemonSyncStrategy) enrich(ctx context.Context, items []entity.ExternalItem) ([]entity.ExternalItem, error) {
+	enricher, ok := p.apiClient.(ItemEnricher)
+	if !ok {
+		return items, nil
+	}
+	return enricher.EnrichItems(ctx, items)
 }
@@ -2,6 +2,8 @@ package strategy
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -97,6 +99,101 @@ func TestPokemonSyncStrategy_FetchAllItems(t *testing.T) {
 	}
 }
 
+func TestPokemonSyncStrategy_FetchAllItems_Checkpoint(t *testing.T) {
+	mockClient := &offsetPokemonAPIClient{failAt: 42}
+
+	strategy := NewPokemonSyncStrategy(nil, mockClient)
+	checkpoint := &entity.SyncCheckpoint{APISource: "pokemon", Offset: 40}
+	request := SyncItemsRequest{
+		APISource:  "pokemon",
+		Operation:  "list",
+		Params:     map[string]interface{}{"limit": float64(2)},
+		Checkpoint: checkpoint,
+	}
+
+	items, err := strategy.FetchAllItems(context.Background(), request)
+
+	assert.Error(t, err)
+	assert.Len(t, items, 2, "Items of the pages before the failure should be returned")
+	assert.Equal(t, []int{40, 42}, mockClient.offsets, "Should resume at the checkpoint offset")
+	assert.Equal(t, 42, checkpoint.Offset, "Checkpoint should point at the page that failed")
+	assert.Equal(t, "https://pokeapi.co/api/v2/pokemon?offset=42&limit=2", checkpoint.NextURL)
+}
+
+func TestPokemonSyncStrategy_StreamAllItems(t *testing.T) {
+	mockClient := &offsetPokemonAPIClient{failAt: 4}
+
+	strategy := NewPokemonSyncStrategy(nil, mockClient)
+	checkpoint := &entity.SyncCheckpoint{APISource: "pokemon"}
+	request := SyncItemsRequest{
+		APISource:  "pokemon",
+		Operation:  "list",
+		Params:     map[string]interface{}{"limit": float64(2)},
+		Checkpoint: checkpoint,
+	}
+
+	var pages [][]int
+	var checkpoints []int
+	err := strategy.StreamAllItems(context.Background(), request, func(ctx context.Context, page Page) error {
+		var ids []int
+		for _, item := range page.Items {
+			ids = append(ids, item.ID)
+		}
+		pages = append(pages, ids)
+		checkpoints = append(checkpoints, checkpoint.Offset)
+		return nil
+	})
+
+	assert.Error(t, err, "Upstream failure should end the stream")
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, pages, "Each page should be handed over on its own")
+	assert.Equal(t, []int{2, 4}, checkpoints, "Checkpoint should already point past the page being handled")
+}
+
+func TestPokemonSyncStrategy_StreamAllItems_HandlerError(t *testing.T) {
+	mockClient := &offsetPokemonAPIClient{failAt: -1}
+
+	strategy := NewPokemonSyncStrategy(nil, mockClient)
+	request := SyncItemsRequest{
+		APISource: "pokemon",
+		Operation: "list",
+		Params:    map[string]interface{}{"limit": float64(2)},
+	}
+
+	handlerErr := errors.New("database unavailable")
+	err := strategy.StreamAllItems(context.Background(), request, func(ctx context.Context, page Page) error {
+		return handlerErr
+	})
+
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Equal(t, []int{0}, mockClient.offsets, "No page should be fetched after the handler failed")
+}
+
+// offsetPokemonAPIClient serves pages of the requested size and fails at offset failAt
+type offsetPokemonAPIClient struct {
+	mockPokemonAPIClient
+	failAt  int
+	offsets []int
+}
+
+func (m *offsetPokemonAPIClient) FetchPaginated(ctx context.Context, apiName string, operation string, params map[string]interface{}) (*api.PaginatedResponse, error) {
+	offset, limit := params["offset"].(int), params["limit"].(int)
+	m.offsets = append(m.offsets, offset)
+	if offset == m.failAt {
+		return nil, errors.New("upstream unavailable")
+	}
+
+	items := make([]entity.ExternalItem, limit)
+	for i := range items {
+		items[i] = entity.ExternalItem{ID: offset + i + 1, Title: "pokemon"}
+	}
+	nextURL := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon?offset=%d&limit=%d", offset+limit, limit)
+
+	return &api.PaginatedResponse{
+		Items:      items,
+		Pagination: api.NewPaginationMetadata(1000, nextURL, ""),
+	}, nil
+}
+
 func TestPokemonSyncStrategy_ParseNextURL(t *testing.T) {
 	strategy := NewPokemonSyncStrategy(nil, nil)
 
@@ -137,3 +234,39 @@ func TestPokemonSyncStrategy_ParseNextURL(t *testing.T) {
 		})
 	}
 }
+
+// enrichingPokemonAPIClient marks every item it enriches
+type enrichingPokemonAPIClient struct {
+	offsetPokemonAPIClient
+}
+
+func (m *enrichingPokemonAPIClient) EnrichItems(ctx context.Context, items []entity.ExternalItem) ([]entity.ExternalItem, error) {
+	enriched := make([]entity.ExternalItem, len(items))
+	for i, item := range items {
+		item.Description = fmt.Sprintf("pokemon %d", item.ID)
+		enriched[i] = item
+	}
+	return enriched, nil
+}
+
+func TestPokemonSyncStrategy_StreamAllItems_Enrichment(t *testing.T) {
+	mockClient := &enrichingPokemonAPIClient{offsetPokemonAPIClient{failAt: 4}}
+
+	strategy := NewPokemonSyncStrategy(nil, mockClient)
+	request := SyncItemsRequest{
+		APISource: "pokemon",
+		Operation: "list",
+		Params:    map[string]interface{}{"limit": float64(2)},
+	}
+
+	var descriptions []string
+	err := strategy.StreamAllItems(context.Background(), request, func(ctx context.Context, page Page) error {
+		for _, item := range page.Items {
+			descriptions = append(descriptions, item.Description)
+		}
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"pokemon 1", "pokemon 2", "pokemon 3", "pokemon 4"}, descriptions, "Pages should be enriched before they are handed over")
+}
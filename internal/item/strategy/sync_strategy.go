@@ -2,16 +2,10 @@ package strategy
 
 import (
 	"context"
-	"errors"
+	"time"
 
-	"github.com/zainokta/item-sync/config"
 	"github.com/zainokta/item-sync/internal/item/entity"
 	"github.com/zainokta/item-sync/pkg/api"
-	"github.com/zainokta/item-sync/pkg/logger"
-)
-
-var (
-	ErrAPISourceNotSupported = errors.New("api source is not supported yet")
 )
 
 // ExternalAPIClient interface for external API calls
@@ -21,34 +15,66 @@ type ExternalAPIClient interface {
 	FetchPaginated(ctx context.Context, apiName string, operation string, params map[string]interface{}) (*api.PaginatedResponse, error)
 }
 
+// ItemEnricher is implemented by clients that can add detail to the items of a listing, such as api.PokemonClient
+type ItemEnricher interface {
+	EnrichItems(ctx context.Context, items []entity.ExternalItem) ([]entity.ExternalItem, error)
+}
+
 // SyncItemsRequest represents the sync request
 type SyncItemsRequest struct {
 	ForceSync bool                   `json:"force_sync"`
 	APISource string                 `json:"api_source"`
 	Operation string                 `json:"operation"`
 	Params    map[string]interface{} `json:"params"`
+
+	// Checkpoint is where FetchAllItems resumes. Strategies that support checkpoints advance it past every page
+	// they return, so after an error it points at the first page that was not fetched.
+	Checkpoint *entity.SyncCheckpoint `json:"-"`
 }
 
+// SyncStrategy fetches the items of a source. FetchAllItems is only trusted to return the whole listing when it
+// succeeds, strategies that may stop early report it through StreamingSyncStrategy.
 type SyncStrategy interface {
 	FetchAllItems(ctx context.Context, request SyncItemsRequest) ([]entity.ExternalItem, error)
 	Fetch(ctx context.Context, request SyncItemsRequest) ([]entity.ExternalItem, error)
 }
 
-func NewSyncStrategy(cfg *config.Config, apiType string, logger logger.Logger) (SyncStrategy, error) {
-	switch apiType {
-	case "pokemon":
-		apiClient, err := api.NewAPIClient(apiType, cfg.API, cfg.Retry, logger)
-		if err != nil {
-			return nil, err
-		}
-		return NewPokemonSyncStrategy(logger, apiClient), nil
-	case "openweather":
-		apiClient, err := api.NewAPIClient(apiType, cfg.API, cfg.Retry, logger)
-		if err != nil {
-			return nil, err
-		}
-		return NewOpenWeatherSyncStrategy(apiClient), nil
-	default:
-		return nil, ErrAPISourceNotSupported
-	}
+// Page is one fetched page of a full sync
+type Page struct {
+	Items []entity.ExternalItem
+
+	// NotModified is set when the upstream reported the page unchanged since it was last fetched. Items then holds
+	// the page as it was last fetched, so that it still counts as seen.
+	NotModified bool
+
+	// Validators are committed by the handler once the items of the page are stored, see api.Validators
+	Validators *api.Validators
+
+	// LastModified is the upstream Last-Modified of a modified page, zero when unknown
+	LastModified time.Time
+
+	// Last is set on the final page of the listing. A stream that ends without it stopped early, for example at a
+	// page cap, and did not see every item.
+	Last bool
+}
+
+// PageHandler receives one fetched page, an error stops the stream and is returned by StreamAllItems
+type PageHandler func(ctx context.Context, page Page) error
+
+// StreamingSyncStrategy hands over the items of a full sync page by page instead of collecting them.
+// The request checkpoint is advanced past a page before its handler runs, so a handler that persists
+// the page can persist the checkpoint along with it. Only the page that ends the listing is marked Last.
+type StreamingSyncStrategy interface {
+	SyncStrategy
+	StreamAllItems(ctx context.Context, request SyncItemsRequest, handle PageHandler) error
+}
+
+// collectAllItems implements FetchAllItems on top of StreamAllItems, returning the pages fetched before an error
+func collectAllItems(ctx context.Context, strategy StreamingSyncStrategy, request SyncItemsRequest) ([]entity.ExternalItem, error) {
+	var allItems []entity.ExternalItem
+	err := strategy.StreamAllItems(ctx, request, func(ctx context.Context, page Page) error {
+		allItems = append(allItems, page.Items...)
+		return nil
+	})
+	return allItems, err
 }
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/pkg/circuit"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Manual circuit breaker actions
+const (
+	BreakerActionForceOpen  = "force-open"
+	BreakerActionForceClose = "force-close"
+	BreakerActionReset      = "reset"
+)
+
+type BreakerActionUseCase struct {
+	breakers BreakerController
+	logger   logger.Logger
+}
+
+type BreakerActionRequest struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+type BreakerActionResponse struct {
+	Breaker circuit.Snapshot `json:"breaker"`
+}
+
+func NewBreakerActionUseCase(breakers BreakerController, logger logger.Logger) *BreakerActionUseCase {
+	return &BreakerActionUseCase{
+		breakers: breakers,
+		logger:   logger,
+	}
+}
+
+func (uc *BreakerActionUseCase) Execute(ctx context.Context, req BreakerActionRequest) (BreakerActionResponse, error) {
+	ctx, span := tracing.Start(ctx, "BreakerActionUseCase.Execute",
+		attribute.String("breaker", req.Name),
+		attribute.String("action", req.Action))
+	defer span.End()
+
+	var apply func(name string) (circuit.Snapshot, error)
+	switch req.Action {
+	case BreakerActionForceOpen:
+		apply = uc.breakers.ForceOpen
+	case BreakerActionForceClose:
+		apply = uc.breakers.ForceClose
+	case BreakerActionReset:
+		apply = uc.breakers.Reset
+	default:
+		return BreakerActionResponse{}, pkgErrors.InvalidBreakerAction(req.Action)
+	}
+
+	snapshot, err := apply(req.Name)
+	if err != nil {
+		if errors.Is(err, circuit.ErrBreakerNotFound) {
+			return BreakerActionResponse{}, pkgErrors.BreakerNotFound(req.Name)
+		}
+		return BreakerActionResponse{}, err
+	}
+
+	uc.logger.Info("Circuit breaker action applied", "name", req.Name, "action", req.Action, "state", snapshot.State)
+
+	return BreakerActionResponse{
+		Breaker: snapshot,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	"github.com/zainokta/item-sync/pkg/circuit"
+	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBreakerActionUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBreakers := mocks.NewMockBreakerController(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	useCase := NewBreakerActionUseCase(mockBreakers, mockLogger)
+
+	snapshot := circuit.Snapshot{Name: "pokemon-api", State: "OPEN", Forced: true, Threshold: 5}
+	mockBreakers.EXPECT().ForceOpen("pokemon-api").Return(snapshot, nil)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	resp, err := useCase.Execute(context.Background(), BreakerActionRequest{Name: "pokemon-api", Action: BreakerActionForceOpen})
+
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, resp.Breaker)
+}
+
+func TestBreakerActionUseCase_Execute_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBreakers := mocks.NewMockBreakerController(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	useCase := NewBreakerActionUseCase(mockBreakers, mockLogger)
+
+	mockBreakers.EXPECT().Reset("unknown-api").Return(circuit.Snapshot{}, circuit.ErrBreakerNotFound)
+
+	_, err := useCase.Execute(context.Background(), BreakerActionRequest{Name: "unknown-api", Action: BreakerActionReset})
+
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "BREAKER_NOT_FOUND", domainErr.Code)
+}
+
+func TestBreakerActionUseCase_Execute_InvalidAction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBreakers := mocks.NewMockBreakerController(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	useCase := NewBreakerActionUseCase(mockBreakers, mockLogger)
+
+	_, err := useCase.Execute(context.Background(), BreakerActionRequest{Name: "pokemon-api", Action: "half-open"})
+
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "INVALID_BREAKER_ACTION", domainErr.Code)
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/zainokta/item-sync/pkg/circuit"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+)
+
+type ListBreakersUseCase struct {
+	breakers BreakerController
+	logger   logger.Logger
+}
+
+type ListBreakersResponse struct {
+	Breakers []circuit.Snapshot `json:"breakers"`
+}
+
+func NewListBreakersUseCase(breakers BreakerController, logger logger.Logger) *ListBreakersUseCase {
+	return &ListBreakersUseCase{
+		breakers: breakers,
+		logger:   logger,
+	}
+}
+
+func (uc *ListBreakersUseCase) Execute(ctx context.Context) (ListBreakersResponse, error) {
+	ctx, span := tracing.Start(ctx, "ListBreakersUseCase.Execute")
+	defer span.End()
+
+	snapshots := uc.breakers.Snapshots()
+	uc.logger.Debug("Listed circuit breakers", "count", len(snapshots))
+
+	return ListBreakersResponse{
+		Breakers: snapshots,
+	}, nil
+}
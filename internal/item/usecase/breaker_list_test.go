@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	"github.com/zainokta/item-sync/pkg/circuit"
+	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListBreakersUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBreakers := mocks.NewMockBreakerController(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	useCase := NewListBreakersUseCase(mockBreakers, mockLogger)
+
+	lastFailure := time.Now()
+	nextRetry := lastFailure.Add(time.Minute)
+	snapshots := []circuit.Snapshot{
+		{Name: "openweather-api", State: "CLOSED", Threshold: 5},
+		{Name: "pokemon-api", State: "OPEN", FailureCount: 5, Threshold: 5, LastFailureAt: &lastFailure, NextRetryAt: &nextRetry},
+	}
+
+	mockBreakers.EXPECT().Snapshots().Return(snapshots)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	resp, err := useCase.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, snapshots, resp.Breakers)
+}
@@ -6,12 +6,16 @@ import (
 
 	"github.com/zainokta/item-sync/internal/item/entity"
 	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/pkg/circuit"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
 )
 
 // ItemSaver interface for saving items
 type ItemSaver interface {
 	Save(ctx context.Context, item entity.Item) error
-	UpsertWithHash(ctx context.Context, apiSource string, externalItem entity.ExternalItem) error
+	UpsertWithHash(ctx context.Context, apiSource string, externalItem entity.ExternalItem) (entity.UpsertOutcome, error)
+	UpsertManyWithHash(ctx context.Context, apiSource string, externalItems []entity.ExternalItem) ([]entity.UpsertResult, error)
+	ReconcileMissingItems(ctx context.Context, apiSource string, seenIDs []int, policy entity.DeletionPolicy) (int, error)
 }
 
 // ItemFinder interface for finding items
@@ -21,6 +25,7 @@ type ItemFinder interface {
 	FindByStatus(ctx context.Context, status string, limit, offset int) ([]entity.Item, error)
 	FindByType(ctx context.Context, itemType string, limit, offset int) ([]entity.Item, error)
 	FindByAPISource(ctx context.Context, apiSource string, limit, offset int) ([]entity.Item, error)
+	FindDeleted(ctx context.Context, apiSource string, limit, offset int) ([]entity.Item, error)
 }
 
 // ItemCache interface for caching
@@ -42,7 +47,38 @@ type ExternalAPIClient interface {
 // JobRepository interface for job management
 type JobRepository interface {
 	CreateSyncJobRecord(ctx context.Context, name string, apiType string) (int64, error)
-	UpdateSyncJobRecord(ctx context.Context, jobID int64, status string, processed, succeeded, failed int, lastErr error, executionTime time.Duration) error
+	UpdateSyncJobRecord(ctx context.Context, jobID int64, status string, stats entity.SyncJobStats, lastErr error, executionTime time.Duration) error
+	FindSyncJobByID(ctx context.Context, jobID int64) (entity.SyncJobRecord, error)
+	ListSyncJobs(ctx context.Context, filter entity.SyncJobFilter) ([]entity.SyncJobRecord, error)
+	CountSyncJobs(ctx context.Context, filter entity.SyncJobFilter) (int, error)
+	FindSyncCheckpoint(ctx context.Context, apiSource string) (*entity.SyncCheckpoint, error)
+	SaveSyncCheckpoint(ctx context.Context, checkpoint entity.SyncCheckpoint) error
+	DeleteSyncCheckpoint(ctx context.Context, apiSource string) error
+}
+
+// ItemVersionRepository interface for reading the change history of items
+type ItemVersionRepository interface {
+	ListItemVersions(ctx context.Context, itemID int, limit, offset int) ([]entity.ItemVersion, error)
+	CountItemVersions(ctx context.Context, itemID int) (int, error)
+	FindItemVersion(ctx context.Context, itemID int, versionID int64) (entity.ItemVersion, error)
+}
+
+// JobCanceller interface for stopping sync jobs running in this process
+type JobCanceller interface {
+	Cancel(jobID int64) bool
+}
+
+// RateLimitReporter interface for reading the outbound rate limiters of the external API clients
+type RateLimitReporter interface {
+	States() []ratelimit.State
+}
+
+// BreakerController interface for reading and overriding the circuit breakers of the external API clients
+type BreakerController interface {
+	Snapshots() []circuit.Snapshot
+	ForceOpen(name string) (circuit.Snapshot, error)
+	ForceClose(name string) (circuit.Snapshot, error)
+	Reset(name string) (circuit.Snapshot, error)
 }
 
 // ItemRepository interface combining saver, finder, and job repository
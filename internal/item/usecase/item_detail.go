@@ -8,15 +8,18 @@ import (
 	"github.com/zainokta/item-sync/config"
 	pkgErrors "github.com/zainokta/item-sync/internal/errors"
 	"github.com/zainokta/item-sync/internal/item/entity"
-	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/internal/item/provider"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type FetchItemUseCase struct {
-	cfg      *config.Config
-	itemRepo ItemRepository
-	cache    ItemCache
-	logger   logger.Logger
+	cfg       *config.Config
+	providers *provider.Registry
+	itemRepo  ItemRepository
+	cache     ItemCache
+	logger    logger.Logger
 }
 
 type FetchItemRequest struct {
@@ -28,16 +31,22 @@ type FetchItemResponse struct {
 	Item entity.Item `json:"item"`
 }
 
-func NewFetchItemUseCase(cfg *config.Config, itemRepo ItemRepository, cache ItemCache, logger logger.Logger) *FetchItemUseCase {
+func NewFetchItemUseCase(cfg *config.Config, providers *provider.Registry, itemRepo ItemRepository, cache ItemCache, logger logger.Logger) *FetchItemUseCase {
 	return &FetchItemUseCase{
-		cfg:      cfg,
-		itemRepo: itemRepo,
-		cache:    cache,
-		logger:   logger,
+		cfg:       cfg,
+		providers: providers,
+		itemRepo:  itemRepo,
+		cache:     cache,
+		logger:    logger,
 	}
 }
 
 func (uc *FetchItemUseCase) Execute(ctx context.Context, req FetchItemRequest) (FetchItemResponse, error) {
+	ctx, span := tracing.Start(ctx, "FetchItemUseCase.Execute",
+		attribute.Int("item.id", req.ID),
+		attribute.String("api_source", req.APISource))
+	defer span.End()
+
 	cacheKey := fmt.Sprintf("item:%d:%s", req.ID, req.APISource)
 	if cachedItem, err := uc.cache.GetItem(ctx, cacheKey); err == nil {
 		return FetchItemResponse{
@@ -54,7 +63,7 @@ func (uc *FetchItemUseCase) Execute(ctx context.Context, req FetchItemRequest) (
 		}, nil
 	}
 
-	apiClient, err := api.NewAPIClient(req.APISource, uc.cfg.API, uc.cfg.Retry, uc.logger)
+	apiClient, err := uc.providers.Client(req.APISource)
 	if err != nil {
 		return FetchItemResponse{}, err
 	}
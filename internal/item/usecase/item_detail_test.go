@@ -10,10 +10,25 @@ import (
 	"github.com/zainokta/item-sync/config"
 	"github.com/zainokta/item-sync/internal/item/entity"
 	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	"github.com/zainokta/item-sync/pkg/fakeupstream"
 	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
 	"go.uber.org/mock/gomock"
 )
 
+// useFakeUpstream points the pokemon client at a local fake upstream and allows the logging of the client plumbing
+func useFakeUpstream(t *testing.T, mockLogger *loggermocks.MockLogger) {
+	t.Helper()
+
+	server, _ := fakeupstream.NewServer(fakeupstream.DefaultCatalog())
+	t.Cleanup(server.Close)
+	t.Setenv("API_POKEMON_BASE_URL", server.URL+fakeupstream.PokemonPath)
+
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+}
+
 func TestFetchItemUseCase_Execute_CacheHit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -32,7 +47,7 @@ func TestFetchItemUseCase_Execute_CacheHit(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewFetchItemUseCase(cfg, mockItemRepo, mockCache, mockLogger)
+	useCase := NewFetchItemUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockCache, mockLogger)
 
 	// Setup request
 	request := FetchItemRequest{
@@ -81,7 +96,7 @@ func TestFetchItemUseCase_Execute_DatabaseHit(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewFetchItemUseCase(cfg, mockItemRepo, mockCache, mockLogger)
+	useCase := NewFetchItemUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockCache, mockLogger)
 
 	// Setup request
 	request := FetchItemRequest{
@@ -125,6 +140,7 @@ func TestFetchItemUseCase_Execute_APIFetch(t *testing.T) {
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockCache := mocks.NewMockItemCache(ctrl)
 	mockLogger := loggermocks.NewMockLogger(ctrl)
+	useFakeUpstream(t, mockLogger)
 
 	// Setup config
 	cfg := &config.Config{
@@ -135,7 +151,7 @@ func TestFetchItemUseCase_Execute_APIFetch(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewFetchItemUseCase(cfg, mockItemRepo, mockCache, mockLogger)
+	useCase := NewFetchItemUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockCache, mockLogger)
 
 	// Setup request
 	request := FetchItemRequest{
@@ -178,7 +194,7 @@ func TestFetchItemUseCase_Execute_APIClientError(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewFetchItemUseCase(cfg, mockItemRepo, mockCache, mockLogger)
+	useCase := NewFetchItemUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockCache, mockLogger)
 
 	// Setup request
 	request := FetchItemRequest{
@@ -210,6 +226,7 @@ func TestFetchItemUseCase_Execute_ExternalAPIError(t *testing.T) {
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockCache := mocks.NewMockItemCache(ctrl)
 	mockLogger := loggermocks.NewMockLogger(ctrl)
+	useFakeUpstream(t, mockLogger)
 
 	// Setup config
 	cfg := &config.Config{
@@ -220,7 +237,7 @@ func TestFetchItemUseCase_Execute_ExternalAPIError(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewFetchItemUseCase(cfg, mockItemRepo, mockCache, mockLogger)
+	useCase := NewFetchItemUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockCache, mockLogger)
 
 	// Setup request
 	request := FetchItemRequest{
@@ -252,6 +269,7 @@ func TestFetchItemUseCase_Execute_ValidationError(t *testing.T) {
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockCache := mocks.NewMockItemCache(ctrl)
 	mockLogger := loggermocks.NewMockLogger(ctrl)
+	useFakeUpstream(t, mockLogger)
 
 	// Setup config
 	cfg := &config.Config{
@@ -262,7 +280,7 @@ func TestFetchItemUseCase_Execute_ValidationError(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewFetchItemUseCase(cfg, mockItemRepo, mockCache, mockLogger)
+	useCase := NewFetchItemUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockCache, mockLogger)
 
 	// Setup request - use an ID that doesn't exist to simulate validation failure
 	request := FetchItemRequest{
@@ -295,6 +313,7 @@ func TestFetchItemUseCase_Execute_SaveError(t *testing.T) {
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockCache := mocks.NewMockItemCache(ctrl)
 	mockLogger := loggermocks.NewMockLogger(ctrl)
+	useFakeUpstream(t, mockLogger)
 
 	// Setup config
 	cfg := &config.Config{
@@ -305,7 +324,7 @@ func TestFetchItemUseCase_Execute_SaveError(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewFetchItemUseCase(cfg, mockItemRepo, mockCache, mockLogger)
+	useCase := NewFetchItemUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockCache, mockLogger)
 
 	// Setup request
 	request := FetchItemRequest{
@@ -348,7 +367,7 @@ func TestFetchItemUseCase_Execute_CacheSetErrorOnDatabaseHit(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewFetchItemUseCase(cfg, mockItemRepo, mockCache, mockLogger)
+	useCase := NewFetchItemUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockCache, mockLogger)
 
 	// Setup request
 	request := FetchItemRequest{
@@ -374,6 +393,9 @@ func TestFetchItemUseCase_Execute_CacheSetErrorOnDatabaseHit(t *testing.T) {
 	mockCache.EXPECT().
 		SetItem(gomock.Any(), "item:25:pokemon", mockItem, 5*time.Minute).
 		Return(assert.AnError)
+	mockLogger.EXPECT().
+		Warn("Failed to cache item", gomock.Any()).
+		Times(1)
 
 	// Execute test
 	response, err := useCase.Execute(context.Background(), request)
@@ -391,6 +413,7 @@ func TestFetchItemUseCase_Execute_CacheSetErrorOnAPIFetch(t *testing.T) {
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockCache := mocks.NewMockItemCache(ctrl)
 	mockLogger := loggermocks.NewMockLogger(ctrl)
+	useFakeUpstream(t, mockLogger)
 
 	// Setup config
 	cfg := &config.Config{
@@ -401,7 +424,7 @@ func TestFetchItemUseCase_Execute_CacheSetErrorOnAPIFetch(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewFetchItemUseCase(cfg, mockItemRepo, mockCache, mockLogger)
+	useCase := NewFetchItemUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockCache, mockLogger)
 
 	// Setup request
 	request := FetchItemRequest{
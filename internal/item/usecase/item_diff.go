@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	"github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ItemDiffUseCase struct {
+	versionRepo ItemVersionRepository
+	logger      logger.Logger
+}
+
+func NewItemDiffUseCase(versionRepo ItemVersionRepository, logger logger.Logger) *ItemDiffUseCase {
+	return &ItemDiffUseCase{
+		versionRepo: versionRepo,
+		logger:      logger,
+	}
+}
+
+type ItemDiffRequest struct {
+	ItemID int   `json:"item_id"`
+	From   int64 `json:"from"`
+	To     int64 `json:"to"`
+}
+
+type ItemDiffResponse struct {
+	From    entity.ItemVersion   `json:"from"`
+	To      entity.ItemVersion   `json:"to"`
+	Changes []entity.FieldChange `json:"changes"`
+}
+
+// Execute compares the content an item had after version From with its content after version To
+func (uc *ItemDiffUseCase) Execute(ctx context.Context, req ItemDiffRequest) (ItemDiffResponse, error) {
+	ctx, span := tracing.Start(ctx, "ItemDiffUseCase.Execute", attribute.Int("item.id", req.ItemID))
+	defer span.End()
+
+	if req.From <= 0 || req.To <= 0 {
+		return ItemDiffResponse{}, errors.InvalidItemData("from and to version IDs are required")
+	}
+
+	from, err := uc.versionRepo.FindItemVersion(ctx, req.ItemID, req.From)
+	if err != nil {
+		return ItemDiffResponse{}, err
+	}
+
+	to, err := uc.versionRepo.FindItemVersion(ctx, req.ItemID, req.To)
+	if err != nil {
+		return ItemDiffResponse{}, err
+	}
+
+	return ItemDiffResponse{
+		From:    from,
+		To:      to,
+		Changes: diffSnapshots(from.NewPayload, to.NewPayload),
+	}, nil
+}
+
+func diffSnapshots(old, new entity.ItemSnapshot) []entity.FieldChange {
+	changes := []entity.FieldChange{}
+	if old.Title != new.Title {
+		changes = append(changes, entity.FieldChange{Path: "title", Type: entity.FieldChanged, OldValue: old.Title, NewValue: new.Title})
+	}
+	return diffValues(changes, "extend_info", old.ExtendInfo, new.ExtendInfo)
+}
+
+// diffValues descends into nested objects so a change is reported at the deepest differing key.
+// Arrays and scalars are compared as a whole.
+func diffValues(changes []entity.FieldChange, path string, old, new interface{}) []entity.FieldChange {
+	oldMap, oldIsMap := old.(map[string]interface{})
+	newMap, newIsMap := new.(map[string]interface{})
+	if !oldIsMap || !newIsMap {
+		if !reflect.DeepEqual(old, new) {
+			changes = append(changes, entity.FieldChange{Path: path, Type: entity.FieldChanged, OldValue: old, NewValue: new})
+		}
+		return changes
+	}
+
+	keys := make([]string, 0, len(oldMap)+len(newMap))
+	for key := range oldMap {
+		keys = append(keys, key)
+	}
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		oldValue, inOld := oldMap[key]
+		newValue, inNew := newMap[key]
+		keyPath := path + "." + key
+
+		switch {
+		case !inOld:
+			changes = append(changes, entity.FieldChange{Path: keyPath, Type: entity.FieldAdded, NewValue: newValue})
+		case !inNew:
+			changes = append(changes, entity.FieldChange{Path: keyPath, Type: entity.FieldRemoved, OldValue: oldValue})
+		default:
+			changes = diffValues(changes, keyPath, oldValue, newValue)
+		}
+	}
+
+	return changes
+}
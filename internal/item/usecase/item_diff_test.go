@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestItemDiffUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockVersionRepo := mocks.NewMockItemVersionRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewItemDiffUseCase(mockVersionRepo, mockLogger)
+
+	// Mock data
+	from := entity.ItemVersion{
+		ID:     1,
+		ItemID: 1,
+		NewPayload: entity.ItemSnapshot{
+			Title: "Pikachu",
+			ExtendInfo: map[string]interface{}{
+				"api_source": "pokemon",
+				"weight":     float64(60),
+				"stats":      map[string]interface{}{"hp": float64(35), "speed": float64(90)},
+				"legacy":     true,
+			},
+		},
+	}
+	to := entity.ItemVersion{
+		ID:     5,
+		ItemID: 1,
+		NewPayload: entity.ItemSnapshot{
+			Title: "Pikachu (Gen 9)",
+			ExtendInfo: map[string]interface{}{
+				"api_source": "pokemon",
+				"weight":     float64(65),
+				"stats":      map[string]interface{}{"hp": float64(35), "speed": float64(90), "attack": float64(55)},
+			},
+		},
+	}
+
+	// Set expectations
+	mockVersionRepo.EXPECT().FindItemVersion(gomock.Any(), 1, int64(1)).Return(from, nil)
+	mockVersionRepo.EXPECT().FindItemVersion(gomock.Any(), 1, int64(5)).Return(to, nil)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), ItemDiffRequest{ItemID: 1, From: 1, To: 5})
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Equal(t, from, response.From)
+	assert.Equal(t, to, response.To)
+	assert.Equal(t, []entity.FieldChange{
+		{Path: "title", Type: entity.FieldChanged, OldValue: "Pikachu", NewValue: "Pikachu (Gen 9)"},
+		{Path: "extend_info.legacy", Type: entity.FieldRemoved, OldValue: true},
+		{Path: "extend_info.stats.attack", Type: entity.FieldAdded, NewValue: float64(55)},
+		{Path: "extend_info.weight", Type: entity.FieldChanged, OldValue: float64(60), NewValue: float64(65)},
+	}, response.Changes)
+}
+
+func TestItemDiffUseCase_Execute_NoChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockVersionRepo := mocks.NewMockItemVersionRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewItemDiffUseCase(mockVersionRepo, mockLogger)
+
+	// Mock data
+	version := entity.ItemVersion{ID: 3, ItemID: 1, NewPayload: entity.ItemSnapshot{Title: "London", ExtendInfo: map[string]interface{}{"temp": float64(12)}}}
+
+	// Set expectations
+	mockVersionRepo.EXPECT().FindItemVersion(gomock.Any(), 1, int64(3)).Return(version, nil).Times(2)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), ItemDiffRequest{ItemID: 1, From: 3, To: 3})
+
+	// Assertions
+	require.NoError(t, err)
+	assert.NotNil(t, response.Changes)
+	assert.Empty(t, response.Changes)
+}
+
+func TestItemDiffUseCase_Execute_MissingVersionIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockVersionRepo := mocks.NewMockItemVersionRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewItemDiffUseCase(mockVersionRepo, mockLogger)
+
+	// Execute test
+	_, err := useCase.Execute(context.Background(), ItemDiffRequest{ItemID: 1, From: 1})
+
+	// Assertions
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "INVALID_ITEM_DATA", domainErr.Code)
+}
+
+func TestItemDiffUseCase_Execute_VersionNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockVersionRepo := mocks.NewMockItemVersionRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewItemDiffUseCase(mockVersionRepo, mockLogger)
+
+	// Set expectations
+	mockVersionRepo.EXPECT().FindItemVersion(gomock.Any(), 1, int64(1)).Return(entity.ItemVersion{ID: 1}, nil)
+	mockVersionRepo.EXPECT().FindItemVersion(gomock.Any(), 1, int64(99)).Return(entity.ItemVersion{}, pkgErrors.ItemVersionNotFound(99))
+
+	// Execute test
+	_, err := useCase.Execute(context.Background(), ItemDiffRequest{ItemID: 1, From: 1, To: 99})
+
+	// Assertions
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "ITEM_VERSION_NOT_FOUND", domainErr.Code)
+	assert.Equal(t, int64(99), domainErr.Details["version_id"])
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ItemHistoryUseCase struct {
+	itemRepo    ItemRepository
+	versionRepo ItemVersionRepository
+	logger      logger.Logger
+}
+
+func NewItemHistoryUseCase(itemRepo ItemRepository, versionRepo ItemVersionRepository, logger logger.Logger) *ItemHistoryUseCase {
+	return &ItemHistoryUseCase{
+		itemRepo:    itemRepo,
+		versionRepo: versionRepo,
+		logger:      logger,
+	}
+}
+
+type ItemHistoryRequest struct {
+	ItemID int `json:"item_id"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+type ItemHistoryResponse struct {
+	Versions   []entity.ItemVersion `json:"versions"`
+	TotalCount int                  `json:"total_count"`
+}
+
+// Execute returns the versions of an item, newest first
+func (uc *ItemHistoryUseCase) Execute(ctx context.Context, req ItemHistoryRequest) (ItemHistoryResponse, error) {
+	ctx, span := tracing.Start(ctx, "ItemHistoryUseCase.Execute", attribute.Int("item.id", req.ItemID))
+	defer span.End()
+
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	if _, err := uc.itemRepo.FindByID(ctx, req.ItemID); err != nil {
+		return ItemHistoryResponse{}, err
+	}
+
+	versions, err := uc.versionRepo.ListItemVersions(ctx, req.ItemID, req.Limit, req.Offset)
+	if err != nil {
+		return ItemHistoryResponse{}, err
+	}
+
+	total, err := uc.versionRepo.CountItemVersions(ctx, req.ItemID)
+	if err != nil {
+		return ItemHistoryResponse{}, err
+	}
+
+	if versions == nil {
+		versions = []entity.ItemVersion{}
+	}
+
+	return ItemHistoryResponse{
+		Versions:   versions,
+		TotalCount: total,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestItemHistoryUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockItemRepo := mocks.NewMockItemRepository(ctrl)
+	mockVersionRepo := mocks.NewMockItemVersionRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewItemHistoryUseCase(mockItemRepo, mockVersionRepo, mockLogger)
+
+	// Mock data
+	jobID := int64(42)
+	mockVersions := []entity.ItemVersion{
+		{
+			ID:           2,
+			ItemID:       1,
+			SyncJobID:    &jobID,
+			PreviousHash: "hash1",
+			ContentHash:  "hash2",
+			OldPayload:   &entity.ItemSnapshot{Title: "Pikachu", ExtendInfo: map[string]interface{}{"weight": float64(60)}},
+			NewPayload:   entity.ItemSnapshot{Title: "Pikachu", ExtendInfo: map[string]interface{}{"weight": float64(65)}},
+		},
+		{
+			ID:          1,
+			ItemID:      1,
+			ContentHash: "hash1",
+			NewPayload:  entity.ItemSnapshot{Title: "Pikachu", ExtendInfo: map[string]interface{}{"weight": float64(60)}},
+		},
+	}
+
+	// Set expectations
+	mockItemRepo.EXPECT().
+		FindByID(gomock.Any(), 1).
+		Return(entity.Item{ID: 1, Title: "Pikachu"}, nil)
+	mockVersionRepo.EXPECT().
+		ListItemVersions(gomock.Any(), 1, 20, 0).
+		Return(mockVersions, nil)
+	mockVersionRepo.EXPECT().
+		CountItemVersions(gomock.Any(), 1).
+		Return(2, nil)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), ItemHistoryRequest{ItemID: 1})
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Equal(t, mockVersions, response.Versions)
+	assert.Equal(t, 2, response.TotalCount)
+}
+
+func TestItemHistoryUseCase_Execute_EmptyHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockItemRepo := mocks.NewMockItemRepository(ctrl)
+	mockVersionRepo := mocks.NewMockItemVersionRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewItemHistoryUseCase(mockItemRepo, mockVersionRepo, mockLogger)
+
+	// Set expectations
+	mockItemRepo.EXPECT().
+		FindByID(gomock.Any(), 1).
+		Return(entity.Item{ID: 1}, nil)
+	mockVersionRepo.EXPECT().
+		ListItemVersions(gomock.Any(), 1, 10, 30).
+		Return(nil, nil)
+	mockVersionRepo.EXPECT().
+		CountItemVersions(gomock.Any(), 1).
+		Return(3, nil)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), ItemHistoryRequest{ItemID: 1, Limit: 10, Offset: 30})
+
+	// Assertions
+	require.NoError(t, err)
+	assert.NotNil(t, response.Versions)
+	assert.Empty(t, response.Versions)
+	assert.Equal(t, 3, response.TotalCount)
+}
+
+func TestItemHistoryUseCase_Execute_ItemNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockItemRepo := mocks.NewMockItemRepository(ctrl)
+	mockVersionRepo := mocks.NewMockItemVersionRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewItemHistoryUseCase(mockItemRepo, mockVersionRepo, mockLogger)
+
+	// Set expectations
+	mockItemRepo.EXPECT().
+		FindByID(gomock.Any(), 404).
+		Return(entity.Item{}, pkgErrors.ItemNotFound())
+
+	// Execute test
+	_, err := useCase.Execute(context.Background(), ItemHistoryRequest{ItemID: 404})
+
+	// Assertions
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "ITEM_NOT_FOUND", domainErr.Code)
+}
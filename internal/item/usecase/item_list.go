@@ -2,12 +2,13 @@ package usecase
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/zainokta/item-sync/internal/errors"
 	"github.com/zainokta/item-sync/internal/item/entity"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type ListItemsUseCase struct {
@@ -30,6 +31,7 @@ type ListItemsRequest struct {
 	ItemType  string `json:"item_type"`
 	Status    string `json:"status"`
 	APISource string `json:"api_source"`
+	Deleted   bool   `json:"deleted"`
 }
 
 type ListItemsResponse struct {
@@ -38,6 +40,9 @@ type ListItemsResponse struct {
 }
 
 func (uc *ListItemsUseCase) Execute(ctx context.Context, req ListItemsRequest) (ListItemsResponse, error) {
+	ctx, span := tracing.Start(ctx, "ListItemsUseCase.Execute", attribute.String("api_source", req.APISource))
+	defer span.End()
+
 	if req.Limit <= 0 {
 		req.Limit = 20
 	}
@@ -45,7 +50,7 @@ func (uc *ListItemsUseCase) Execute(ctx context.Context, req ListItemsRequest) (
 		req.Offset = 0
 	}
 
-	cacheKey := fmt.Sprintf("items:%s:%s:%d:%d", req.APISource, req.Status, req.Limit, req.Offset)
+	cacheKey := entity.ItemListCacheKey(req.APISource, req.Status, req.Deleted, req.Limit, req.Offset)
 
 	if cachedItems, err := uc.cache.GetItems(ctx, cacheKey); err == nil {
 		return ListItemsResponse{
@@ -56,7 +61,10 @@ func (uc *ListItemsUseCase) Execute(ctx context.Context, req ListItemsRequest) (
 	var items []entity.Item
 	var err error
 
+	// Soft-deleted items are only listed on request
 	switch {
+	case req.Deleted:
+		items, err = uc.itemRepo.FindDeleted(ctx, req.APISource, req.Limit, req.Offset)
 	case req.APISource != "":
 		items, err = uc.itemRepo.FindByAPISource(ctx, req.APISource, req.Limit, req.Offset)
 	case req.Status != "":
@@ -299,6 +299,9 @@ func TestListItemsUseCase_Execute_CacheSetError(t *testing.T) {
 	mockCache.EXPECT().
 		SetItems(gomock.Any(), "items:::10:0", mockItems, 10*time.Minute).
 		Return(assert.AnError)
+	mockLogger.EXPECT().
+		Warn("Failed to cache items", gomock.Any()).
+		Times(1)
 
 	// Execute test
 	response, err := useCase.Execute(context.Background(), request)
@@ -342,4 +345,49 @@ func TestListItemsUseCase_Execute_RepositoryError(t *testing.T) {
 	// Assertions
 	require.Error(t, err)
 	assert.Equal(t, ListItemsResponse{}, response)
-}
\ No newline at end of file
+}
+func TestListItemsUseCase_Execute_DeletedItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockItemRepo := mocks.NewMockItemRepository(ctrl)
+	mockCache := mocks.NewMockItemCache(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewListItemsUseCase(mockItemRepo, mockCache, mockLogger)
+
+	// Setup request
+	request := ListItemsRequest{
+		Limit:     10,
+		Offset:    0,
+		APISource: "pokemon",
+		Deleted:   true,
+	}
+
+	// Mock data
+	deletedAt := time.Date(2024, 1, 16, 10, 30, 0, 0, time.UTC)
+	mockItems := []entity.Item{
+		{ID: 3, Title: "Missingno", APISource: "pokemon", DeletedAt: &deletedAt},
+	}
+
+	// Set expectations
+	mockCache.EXPECT().
+		GetItems(gomock.Any(), "items:deleted:pokemon:10:0").
+		Return([]entity.Item{}, assert.AnError) // Cache miss
+	mockItemRepo.EXPECT().
+		FindDeleted(gomock.Any(), "pokemon", 10, 0).
+		Return(mockItems, nil)
+	mockCache.EXPECT().
+		SetItems(gomock.Any(), "items:deleted:pokemon:10:0", mockItems, 10*time.Minute).
+		Return(nil)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), request)
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Equal(t, mockItems, response.Items)
+	assert.Equal(t, 1, response.TotalCount)
+}
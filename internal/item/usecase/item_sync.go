@@ -2,27 +2,38 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
 	"github.com/zainokta/item-sync/config"
 	pkgErrors "github.com/zainokta/item-sync/internal/errors"
 	"github.com/zainokta/item-sync/internal/item/jobs"
-	"github.com/zainokta/item-sync/pkg/api"
+	"github.com/zainokta/item-sync/internal/item/provider"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+const manualSyncJobName = "manual_sync"
+
 type SyncItemsUseCase struct {
-	cfg      *config.Config
-	itemRepo ItemRepository
-	jobRepo  JobRepository
-	logger   logger.Logger
+	cfg         *config.Config
+	providers   *provider.Registry
+	itemRepo    ItemRepository
+	jobRepo     JobRepository
+	cache       ItemCache
+	jobRegistry *jobs.JobRegistry
+	logger      logger.Logger
 }
 
-func NewSyncItemsUseCase(cfg *config.Config, itemRepo ItemRepository, jobRepo JobRepository, logger logger.Logger) *SyncItemsUseCase {
+func NewSyncItemsUseCase(cfg *config.Config, providers *provider.Registry, itemRepo ItemRepository, jobRepo JobRepository, cache ItemCache, jobRegistry *jobs.JobRegistry, logger logger.Logger) *SyncItemsUseCase {
 	return &SyncItemsUseCase{
-		cfg:      cfg,
-		itemRepo: itemRepo,
-		jobRepo:  jobRepo,
-		logger:   logger,
+		cfg:         cfg,
+		providers:   providers,
+		itemRepo:    itemRepo,
+		jobRepo:     jobRepo,
+		cache:       cache,
+		jobRegistry: jobRegistry,
+		logger:      logger,
 	}
 }
 
@@ -34,14 +45,27 @@ type SyncItemsRequest struct {
 }
 
 type SyncItemsResponse struct {
+	JobID   int64    `json:"job_id"`
 	Errors  []string `json:"errors,omitempty"`
 	Status  string   `json:"status"`
 	Message string   `json:"message"`
 }
 
 func (uc *SyncItemsUseCase) Execute(ctx context.Context, req SyncItemsRequest) (SyncItemsResponse, error) {
-	// Create API client based on the requested API source
-	apiClient, err := api.NewAPIClient(req.APISource, uc.cfg.API, uc.cfg.Retry, uc.logger)
+	ctx, span := tracing.Start(ctx, "SyncItemsUseCase.Execute",
+		attribute.String("api_source", req.APISource),
+		attribute.String("operation", req.Operation),
+		attribute.Bool("force_sync", req.ForceSync))
+	defer span.End()
+
+	// Create API client and sync strategy based on the requested API source
+	syncProvider, err := uc.providers.Get(req.APISource)
+	if err != nil {
+		uc.logger.Error("Failed to create API client", "api_source", req.APISource, "error", err)
+		return SyncItemsResponse{}, pkgErrors.ExternalAPIFailed(err)
+	}
+
+	syncStrategy, err := uc.providers.SyncStrategy(req.APISource)
 	if err != nil {
 		uc.logger.Error("Failed to create API client", "api_source", req.APISource, "error", err)
 		return SyncItemsResponse{}, pkgErrors.ExternalAPIFailed(err)
@@ -49,32 +73,63 @@ func (uc *SyncItemsUseCase) Execute(ctx context.Context, req SyncItemsRequest) (
 
 	// Create sync job instance
 	syncJob := jobs.NewSyncJob(
-		"manual_sync",
+		manualSyncJobName,
 		uc.itemRepo,
 		uc.jobRepo,
-		apiClient,
+		uc.cache,
+		uc.jobRegistry,
+		syncStrategy,
 		req.APISource,
+		syncProvider.Operation,
 		uc.logger,
 		*uc.cfg,
-		req.Params,
-	)
+		syncProvider.JobParams(req.Params),
+	).WithNarrowedParams(syncProvider.NarrowsListing(req.Params))
+
+	// Claim the source before creating a record, so a run rejected by the overlap policy is answered with a conflict.
+	// The run continues the trace of the request but must outlive it.
+	runCtx := context.WithoutCancel(ctx)
+	claim, err := syncJob.Claim(runCtx)
+	var conflict *jobs.JobConflictError
+	if errors.As(err, &conflict) {
+		uc.logger.Info("Rejecting manual sync, source is already syncing", "api_source", req.APISource, "running_job_id", conflict.RunningJobID)
+		return SyncItemsResponse{}, pkgErrors.SyncJobAlreadyRunning(conflict.APISource, conflict.RunningJobID)
+	}
+	if err != nil {
+		return SyncItemsResponse{}, err
+	}
+
+	// Create the job record up front so the caller can track it
+	jobID, err := uc.jobRepo.CreateSyncJobRecord(ctx, manualSyncJobName, req.APISource)
+	if err != nil {
+		if claim != nil {
+			claim.Release()
+		}
+		uc.logger.Error("Failed to create sync job record", "api_source", req.APISource, "error", err)
+		return SyncItemsResponse{}, pkgErrors.DatabaseError(err)
+	}
+	if claim != nil {
+		// Bound right away so that the run can be cancelled as soon as its ID is returned
+		claim.Bind(jobID)
+	}
 
 	// Start background sync job
-	go uc.executeBackgroundSync(context.Background(), syncJob)
+	go uc.executeBackgroundSync(runCtx, syncJob, jobID, claim)
 
 	return SyncItemsResponse{
+		JobID:   jobID,
 		Errors:  make([]string, 0),
 		Status:  "accepted",
 		Message: "Sync job has been accepted for background processing",
 	}, nil
 }
 
-func (uc *SyncItemsUseCase) executeBackgroundSync(ctx context.Context, syncJob *jobs.SyncJob) {
-	uc.logger.Info("Starting background sync job", "job_name", syncJob.Name())
+func (uc *SyncItemsUseCase) executeBackgroundSync(ctx context.Context, syncJob *jobs.SyncJob, jobID int64, claim *jobs.Claim) {
+	uc.logger.Info("Starting background sync job", "job_name", syncJob.Name(), "job_id", jobID)
 
-	if err := syncJob.Execute(ctx); err != nil {
-		uc.logger.Error("Background sync job failed", "job_name", syncJob.Name(), "error", err)
+	if err := syncJob.Run(ctx, jobID, claim); err != nil {
+		uc.logger.Error("Background sync job failed", "job_name", syncJob.Name(), "job_id", jobID, "error", err)
 	} else {
-		uc.logger.Info("Background sync job completed successfully", "job_name", syncJob.Name())
+		uc.logger.Info("Background sync job completed successfully", "job_name", syncJob.Name(), "job_id", jobID)
 	}
 }
@@ -2,17 +2,64 @@ package usecase
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zainokta/item-sync/config"
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/internal/item/jobs"
+	"github.com/zainokta/item-sync/internal/item/provider"
 	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	"github.com/zainokta/item-sync/pkg/logger"
 	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
 	"go.uber.org/mock/gomock"
 )
 
+// newEmptyUpstream stands in for the upstream APIs with an empty listing, so that background runs finish quickly
+func newEmptyUpstream(t *testing.T) string {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count":0,"results":[]}`))
+	}))
+	t.Cleanup(upstream.Close)
+	return upstream.URL
+}
+
+// expectRunFinished expects the final record update of one background run and returns a channel closed by it
+func expectRunFinished(mockJobRepo *mocks.MockJobRepository) <-chan struct{} {
+	finished := make(chan struct{})
+	mockJobRepo.EXPECT().
+		UpdateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, jobID int64, status string, stats entity.SyncJobStats, lastErr error, executionTime time.Duration) error {
+			close(finished)
+			return nil
+		})
+	return finished
+}
+
+// waitForRun waits for the background run started by Execute to record its outcome
+func waitForRun(t *testing.T, finished <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Background sync should have completed")
+	}
+}
+
+// newTestProviders returns the built-in providers with their clients built from cfg, as at startup
+func newTestProviders(t *testing.T, cfg *config.Config) *provider.Registry {
+	t.Helper()
+	providers := provider.NewDefaultRegistry()
+	require.NoError(t, providers.Build(cfg.API, cfg.Retry, logger.NewLogger(logger.LevelError, "test")))
+	return providers
+}
+
 func TestSyncItemsUseCase_Execute_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -20,18 +67,18 @@ func TestSyncItemsUseCase_Execute_Success(t *testing.T) {
 	// Setup mocks
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockJobRepo := mocks.NewMockJobRepository(ctrl)
-	mockLogger := loggermocks.NewMockLogger(ctrl)
 
 	// Setup config
 	cfg := &config.Config{
 		API: config.APIConfig{
+			BaseURL: newEmptyUpstream(t),
 			Timeout: 30 * time.Second,
 		},
 		Retry: config.RetryConfig{},
 	}
 
 	// Create usecase
-	useCase := NewSyncItemsUseCase(cfg, mockItemRepo, mockJobRepo, mockLogger)
+	useCase := NewSyncItemsUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockJobRepo, mocks.NewMockItemCache(ctrl), jobs.NewJobRegistry(), logger.NewLogger(logger.LevelError, "test"))
 
 	// Setup request
 	request := SyncItemsRequest{
@@ -45,30 +92,33 @@ func TestSyncItemsUseCase_Execute_Success(t *testing.T) {
 	mockJobRepo.EXPECT().
 		CreateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(int64(1), nil).AnyTimes()
+	finished := expectRunFinished(mockJobRepo)
+	mockItemRepo.EXPECT().
+		UpsertManyWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		ReconcileMissingItems(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(0, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		FindByAPISource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockJobRepo.EXPECT().
+		FindSyncCheckpoint(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
 	mockJobRepo.EXPECT().
-		UpdateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		SaveSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
-	mockItemRepo.EXPECT().
-		UpsertWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+	mockJobRepo.EXPECT().
+		DeleteSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
 
-	// Expect logger calls for background sync
-	mockLogger.EXPECT().
-		Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-	mockLogger.EXPECT().
-		Info(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-
-	// Expect logger calls for background sync
-	mockLogger.EXPECT().
-		Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-	mockLogger.EXPECT().
-		Info(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-
 	// Execute test
 	response, err := useCase.Execute(context.Background(), request)
+	waitForRun(t, finished)
 
 	// Assertions
 	require.NoError(t, err)
+	assert.Equal(t, int64(1), response.JobID)
 	assert.Equal(t, "accepted", response.Status)
 	assert.Equal(t, "Sync job has been accepted for background processing", response.Message)
 }
@@ -91,7 +141,7 @@ func TestSyncItemsUseCase_Execute_APIClientCreationFailure(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewSyncItemsUseCase(cfg, mockItemRepo, mockJobRepo, mockLogger)
+	useCase := NewSyncItemsUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockJobRepo, mocks.NewMockItemCache(ctrl), jobs.NewJobRegistry(), mockLogger)
 
 	// Setup request
 	request := SyncItemsRequest{
@@ -117,18 +167,18 @@ func TestSyncItemsUseCase_Execute_WithNilParams(t *testing.T) {
 	// Setup mocks
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockJobRepo := mocks.NewMockJobRepository(ctrl)
-	mockLogger := loggermocks.NewMockLogger(ctrl)
 
 	// Setup config
 	cfg := &config.Config{
 		API: config.APIConfig{
+			BaseURL: newEmptyUpstream(t),
 			Timeout: 30 * time.Second,
 		},
 		Retry: config.RetryConfig{},
 	}
 
 	// Create usecase
-	useCase := NewSyncItemsUseCase(cfg, mockItemRepo, mockJobRepo, mockLogger)
+	useCase := NewSyncItemsUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockJobRepo, mocks.NewMockItemCache(ctrl), jobs.NewJobRegistry(), logger.NewLogger(logger.LevelError, "test"))
 
 	// Setup request with nil params
 	request := SyncItemsRequest{
@@ -140,21 +190,29 @@ func TestSyncItemsUseCase_Execute_WithNilParams(t *testing.T) {
 	mockJobRepo.EXPECT().
 		CreateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(int64(1), nil).AnyTimes()
+	finished := expectRunFinished(mockJobRepo)
+	mockItemRepo.EXPECT().
+		UpsertManyWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		ReconcileMissingItems(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(0, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		FindByAPISource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
 	mockJobRepo.EXPECT().
-		UpdateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		FindSyncCheckpoint(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockJobRepo.EXPECT().
+		SaveSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
-	mockItemRepo.EXPECT().
-		UpsertWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+	mockJobRepo.EXPECT().
+		DeleteSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
 
-	// Expect logger calls for background sync
-	mockLogger.EXPECT().
-		Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-	mockLogger.EXPECT().
-		Info(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-
 	// Execute test
 	response, err := useCase.Execute(context.Background(), request)
+	waitForRun(t, finished)
 
 	// Assertions
 	require.NoError(t, err)
@@ -168,18 +226,18 @@ func TestSyncItemsUseCase_Execute_WithEmptyParams(t *testing.T) {
 	// Setup mocks
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockJobRepo := mocks.NewMockJobRepository(ctrl)
-	mockLogger := loggermocks.NewMockLogger(ctrl)
 
 	// Setup config
 	cfg := &config.Config{
 		API: config.APIConfig{
+			BaseURL: newEmptyUpstream(t),
 			Timeout: 30 * time.Second,
 		},
 		Retry: config.RetryConfig{},
 	}
 
 	// Create usecase
-	useCase := NewSyncItemsUseCase(cfg, mockItemRepo, mockJobRepo, mockLogger)
+	useCase := NewSyncItemsUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockJobRepo, mocks.NewMockItemCache(ctrl), jobs.NewJobRegistry(), logger.NewLogger(logger.LevelError, "test"))
 
 	// Setup request with empty params
 	request := SyncItemsRequest{
@@ -191,21 +249,29 @@ func TestSyncItemsUseCase_Execute_WithEmptyParams(t *testing.T) {
 	mockJobRepo.EXPECT().
 		CreateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(int64(1), nil).AnyTimes()
+	finished := expectRunFinished(mockJobRepo)
+	mockItemRepo.EXPECT().
+		UpsertManyWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		ReconcileMissingItems(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(0, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		FindByAPISource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockJobRepo.EXPECT().
+		FindSyncCheckpoint(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
 	mockJobRepo.EXPECT().
-		UpdateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		SaveSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
-	mockItemRepo.EXPECT().
-		UpsertWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+	mockJobRepo.EXPECT().
+		DeleteSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
 
-	// Expect logger calls for background sync
-	mockLogger.EXPECT().
-		Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-	mockLogger.EXPECT().
-		Info(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-
 	// Execute test
 	response, err := useCase.Execute(context.Background(), request)
+	waitForRun(t, finished)
 
 	// Assertions
 	require.NoError(t, err)
@@ -219,18 +285,18 @@ func TestSyncItemsUseCase_Execute_OpenWeatherAPI(t *testing.T) {
 	// Setup mocks
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockJobRepo := mocks.NewMockJobRepository(ctrl)
-	mockLogger := loggermocks.NewMockLogger(ctrl)
 
 	// Setup config
 	cfg := &config.Config{
 		API: config.APIConfig{
+			BaseURL: newEmptyUpstream(t),
 			Timeout: 30 * time.Second,
 		},
 		Retry: config.RetryConfig{},
 	}
 
 	// Create usecase
-	useCase := NewSyncItemsUseCase(cfg, mockItemRepo, mockJobRepo, mockLogger)
+	useCase := NewSyncItemsUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockJobRepo, mocks.NewMockItemCache(ctrl), jobs.NewJobRegistry(), logger.NewLogger(logger.LevelError, "test"))
 
 	// Setup request for OpenWeather
 	request := SyncItemsRequest{
@@ -244,15 +310,29 @@ func TestSyncItemsUseCase_Execute_OpenWeatherAPI(t *testing.T) {
 	mockJobRepo.EXPECT().
 		CreateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(int64(1), nil).AnyTimes()
+	finished := expectRunFinished(mockJobRepo)
+	mockItemRepo.EXPECT().
+		UpsertManyWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		ReconcileMissingItems(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(0, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		FindByAPISource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
 	mockJobRepo.EXPECT().
-		UpdateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		FindSyncCheckpoint(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockJobRepo.EXPECT().
+		SaveSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
-	mockItemRepo.EXPECT().
-		UpsertWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+	mockJobRepo.EXPECT().
+		DeleteSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
 
 	// Execute test
 	response, err := useCase.Execute(context.Background(), request)
+	waitForRun(t, finished)
 
 	// Assertions
 	require.NoError(t, err)
@@ -278,20 +358,26 @@ func TestSyncItemsUseCase_Execute_JobRepositoryError(t *testing.T) {
 	}
 
 	// Create usecase
-	useCase := NewSyncItemsUseCase(cfg, mockItemRepo, mockJobRepo, mockLogger)
+	useCase := NewSyncItemsUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockJobRepo, mocks.NewMockItemCache(ctrl), jobs.NewJobRegistry(), mockLogger)
 
 	// Setup request
 	request := SyncItemsRequest{
 		APISource: "pokemon",
 	}
 
-	// Execute test - should always return accepted for async processing
+	// Job record is created before the background sync starts
+	mockJobRepo.EXPECT().
+		CreateSyncJobRecord(gomock.Any(), "manual_sync", "pokemon").
+		Return(int64(0), assert.AnError)
+	mockLogger.EXPECT().
+		Error("Failed to create sync job record", "api_source", "pokemon", "error", assert.AnError)
+
+	// Execute test
 	response, err := useCase.Execute(context.Background(), request)
 
-	// Assertions - Execute should always return accepted for async processing
-	require.NoError(t, err)
-	assert.Equal(t, "accepted", response.Status)
-	assert.Equal(t, "Sync job has been accepted for background processing", response.Message)
+	// Assertions - no background job is started without a job record
+	require.Error(t, err)
+	assert.Equal(t, SyncItemsResponse{}, response)
 }
 
 func TestSyncItemsUseCase_Execute_WithForceSync(t *testing.T) {
@@ -301,18 +387,18 @@ func TestSyncItemsUseCase_Execute_WithForceSync(t *testing.T) {
 	// Setup mocks
 	mockItemRepo := mocks.NewMockItemRepository(ctrl)
 	mockJobRepo := mocks.NewMockJobRepository(ctrl)
-	mockLogger := loggermocks.NewMockLogger(ctrl)
 
 	// Setup config
 	cfg := &config.Config{
 		API: config.APIConfig{
+			BaseURL: newEmptyUpstream(t),
 			Timeout: 30 * time.Second,
 		},
 		Retry: config.RetryConfig{},
 	}
 
 	// Create usecase
-	useCase := NewSyncItemsUseCase(cfg, mockItemRepo, mockJobRepo, mockLogger)
+	useCase := NewSyncItemsUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockJobRepo, mocks.NewMockItemCache(ctrl), jobs.NewJobRegistry(), logger.NewLogger(logger.LevelError, "test"))
 
 	// Setup request with force sync
 	request := SyncItemsRequest{
@@ -327,18 +413,151 @@ func TestSyncItemsUseCase_Execute_WithForceSync(t *testing.T) {
 	mockJobRepo.EXPECT().
 		CreateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(int64(1), nil).AnyTimes()
+	finished := expectRunFinished(mockJobRepo)
+	mockItemRepo.EXPECT().
+		UpsertManyWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		ReconcileMissingItems(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(0, nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		FindByAPISource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
 	mockJobRepo.EXPECT().
-		UpdateSyncJobRecord(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		FindSyncCheckpoint(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockJobRepo.EXPECT().
+		SaveSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
-	mockItemRepo.EXPECT().
-		UpsertWithHash(gomock.Any(), gomock.Any(), gomock.Any()).
+	mockJobRepo.EXPECT().
+		DeleteSyncCheckpoint(gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
 
 	// Execute test
 	response, err := useCase.Execute(context.Background(), request)
+	waitForRun(t, finished)
 
 	// Assertions
 	require.NoError(t, err)
 	assert.Equal(t, "accepted", response.Status)
 	assert.Equal(t, "Sync job has been accepted for background processing", response.Message)
 }
+
+func TestSyncItemsUseCase_Execute_SourceAlreadySyncing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockItemRepo := mocks.NewMockItemRepository(ctrl)
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Setup config, the default overlap policy is skip
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Timeout: 30 * time.Second,
+		},
+		Retry: config.RetryConfig{},
+	}
+
+	// Another job is already syncing pokemon
+	jobRegistry := jobs.NewJobRegistry()
+	claim, err := jobRegistry.Claim(context.Background(), "pokemon", entity.OverlapSkip)
+	require.NoError(t, err)
+	claim.Bind(7)
+	defer claim.Release()
+
+	// Create usecase
+	useCase := NewSyncItemsUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockJobRepo, mocks.NewMockItemCache(ctrl), jobRegistry, mockLogger)
+
+	// Set expectations - no job record is created for a rejected request
+	mockLogger.EXPECT().
+		Info("Rejecting manual sync, source is already syncing", "api_source", "pokemon", "running_job_id", int64(7))
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), SyncItemsRequest{APISource: "pokemon"})
+
+	// Assertions
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, pkgErrors.CategoryConflict, domainErr.Category)
+	assert.Equal(t, int64(7), domainErr.Details["running_job_id"])
+	assert.Equal(t, SyncItemsResponse{}, response)
+}
+
+func TestSyncItemsUseCase_Execute_ConcurrentRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockItemRepo := mocks.NewMockItemRepository(ctrl)
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+
+	// An empty listing ends the accepted run right after its checkpoint is loaded
+	cfg := &config.Config{
+		API: config.APIConfig{
+			BaseURL: newEmptyUpstream(t),
+			Timeout: 5 * time.Second,
+		},
+	}
+
+	useCase := NewSyncItemsUseCase(cfg, newTestProviders(t, cfg), mockItemRepo, mockJobRepo, mocks.NewMockItemCache(ctrl), jobs.NewJobRegistry(), logger.NewLogger(logger.LevelError, "test"))
+
+	// The accepted run holds the source until the test lets it load its checkpoint
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	mockJobRepo.EXPECT().
+		CreateSyncJobRecord(gomock.Any(), manualSyncJobName, "pokemon").
+		Return(int64(1), nil).Times(1)
+	mockJobRepo.EXPECT().
+		FindSyncCheckpoint(gomock.Any(), "pokemon").
+		DoAndReturn(func(ctx context.Context, source string) (*entity.SyncCheckpoint, error) {
+			<-release
+			return nil, nil
+		})
+	mockJobRepo.EXPECT().
+		DeleteSyncCheckpoint(gomock.Any(), "pokemon").
+		Return(nil).AnyTimes()
+	mockItemRepo.EXPECT().
+		FindByAPISource(gomock.Any(), "pokemon", 1, 0).
+		Return(nil, nil).AnyTimes()
+	mockJobRepo.EXPECT().
+		UpdateSyncJobRecord(gomock.Any(), int64(1), entity.SyncJobStatusCompleted, gomock.Any(), nil, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, jobID int64, status string, stats entity.SyncJobStats, lastErr error, executionTime time.Duration) error {
+			close(finished)
+			return nil
+		})
+
+	// Both requests arrive while no run of the source has started yet
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	results := make([]error, 2)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, results[i] = useCase.Execute(context.Background(), SyncItemsRequest{APISource: "pokemon"})
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// Exactly one request is accepted, the other is answered with a conflict instead of a failed record
+	var accepted, conflicts int
+	for _, err := range results {
+		var domainErr *pkgErrors.DomainError
+		switch {
+		case err == nil:
+			accepted++
+		case assert.ErrorAs(t, err, &domainErr):
+			assert.Equal(t, pkgErrors.CategoryConflict, domainErr.Category)
+			conflicts++
+		}
+	}
+	assert.Equal(t, 1, accepted)
+	assert.Equal(t, 1, conflicts)
+
+	close(release)
+	waitForRun(t, finished)
+}
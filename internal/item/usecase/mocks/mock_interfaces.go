@@ -16,6 +16,8 @@ import (
 
 	entity "github.com/zainokta/item-sync/internal/item/entity"
 	api "github.com/zainokta/item-sync/pkg/api"
+	circuit "github.com/zainokta/item-sync/pkg/circuit"
+	ratelimit "github.com/zainokta/item-sync/pkg/ratelimit"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,6 +45,21 @@ func (m *MockItemSaver) EXPECT() *MockItemSaverMockRecorder {
 	return m.recorder
 }
 
+// ReconcileMissingItems mocks base method.
+func (m *MockItemSaver) ReconcileMissingItems(ctx context.Context, apiSource string, seenIDs []int, policy entity.DeletionPolicy) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileMissingItems", ctx, apiSource, seenIDs, policy)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileMissingItems indicates an expected call of ReconcileMissingItems.
+func (mr *MockItemSaverMockRecorder) ReconcileMissingItems(ctx, apiSource, seenIDs, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileMissingItems", reflect.TypeOf((*MockItemSaver)(nil).ReconcileMissingItems), ctx, apiSource, seenIDs, policy)
+}
+
 // Save mocks base method.
 func (m *MockItemSaver) Save(ctx context.Context, item entity.Item) error {
 	m.ctrl.T.Helper()
@@ -57,12 +74,28 @@ func (mr *MockItemSaverMockRecorder) Save(ctx, item any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockItemSaver)(nil).Save), ctx, item)
 }
 
+// UpsertManyWithHash mocks base method.
+func (m *MockItemSaver) UpsertManyWithHash(ctx context.Context, apiSource string, externalItems []entity.ExternalItem) ([]entity.UpsertResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertManyWithHash", ctx, apiSource, externalItems)
+	ret0, _ := ret[0].([]entity.UpsertResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertManyWithHash indicates an expected call of UpsertManyWithHash.
+func (mr *MockItemSaverMockRecorder) UpsertManyWithHash(ctx, apiSource, externalItems any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertManyWithHash", reflect.TypeOf((*MockItemSaver)(nil).UpsertManyWithHash), ctx, apiSource, externalItems)
+}
+
 // UpsertWithHash mocks base method.
-func (m *MockItemSaver) UpsertWithHash(ctx context.Context, apiSource string, externalItem entity.ExternalItem) error {
+func (m *MockItemSaver) UpsertWithHash(ctx context.Context, apiSource string, externalItem entity.ExternalItem) (entity.UpsertOutcome, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpsertWithHash", ctx, apiSource, externalItem)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(entity.UpsertOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpsertWithHash indicates an expected call of UpsertWithHash.
@@ -170,6 +203,21 @@ func (mr *MockItemFinderMockRecorder) FindByType(ctx, itemType, limit, offset an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByType", reflect.TypeOf((*MockItemFinder)(nil).FindByType), ctx, itemType, limit, offset)
 }
 
+// FindDeleted mocks base method.
+func (m *MockItemFinder) FindDeleted(ctx context.Context, apiSource string, limit, offset int) ([]entity.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeleted", ctx, apiSource, limit, offset)
+	ret0, _ := ret[0].([]entity.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeleted indicates an expected call of FindDeleted.
+func (mr *MockItemFinderMockRecorder) FindDeleted(ctx, apiSource, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeleted", reflect.TypeOf((*MockItemFinder)(nil).FindDeleted), ctx, apiSource, limit, offset)
+}
+
 // MockItemCache is a mock of ItemCache interface.
 type MockItemCache struct {
 	ctrl     *gomock.Controller
@@ -359,6 +407,21 @@ func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountSyncJobs mocks base method.
+func (m *MockJobRepository) CountSyncJobs(ctx context.Context, filter entity.SyncJobFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSyncJobs", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSyncJobs indicates an expected call of CountSyncJobs.
+func (mr *MockJobRepositoryMockRecorder) CountSyncJobs(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSyncJobs", reflect.TypeOf((*MockJobRepository)(nil).CountSyncJobs), ctx, filter)
+}
+
 // CreateSyncJobRecord mocks base method.
 func (m *MockJobRepository) CreateSyncJobRecord(ctx context.Context, name, apiType string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -374,18 +437,319 @@ func (mr *MockJobRepositoryMockRecorder) CreateSyncJobRecord(ctx, name, apiType
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSyncJobRecord", reflect.TypeOf((*MockJobRepository)(nil).CreateSyncJobRecord), ctx, name, apiType)
 }
 
+// DeleteSyncCheckpoint mocks base method.
+func (m *MockJobRepository) DeleteSyncCheckpoint(ctx context.Context, apiSource string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSyncCheckpoint", ctx, apiSource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSyncCheckpoint indicates an expected call of DeleteSyncCheckpoint.
+func (mr *MockJobRepositoryMockRecorder) DeleteSyncCheckpoint(ctx, apiSource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSyncCheckpoint", reflect.TypeOf((*MockJobRepository)(nil).DeleteSyncCheckpoint), ctx, apiSource)
+}
+
+// FindSyncCheckpoint mocks base method.
+func (m *MockJobRepository) FindSyncCheckpoint(ctx context.Context, apiSource string) (*entity.SyncCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSyncCheckpoint", ctx, apiSource)
+	ret0, _ := ret[0].(*entity.SyncCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSyncCheckpoint indicates an expected call of FindSyncCheckpoint.
+func (mr *MockJobRepositoryMockRecorder) FindSyncCheckpoint(ctx, apiSource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSyncCheckpoint", reflect.TypeOf((*MockJobRepository)(nil).FindSyncCheckpoint), ctx, apiSource)
+}
+
+// FindSyncJobByID mocks base method.
+func (m *MockJobRepository) FindSyncJobByID(ctx context.Context, jobID int64) (entity.SyncJobRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSyncJobByID", ctx, jobID)
+	ret0, _ := ret[0].(entity.SyncJobRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSyncJobByID indicates an expected call of FindSyncJobByID.
+func (mr *MockJobRepositoryMockRecorder) FindSyncJobByID(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSyncJobByID", reflect.TypeOf((*MockJobRepository)(nil).FindSyncJobByID), ctx, jobID)
+}
+
+// ListSyncJobs mocks base method.
+func (m *MockJobRepository) ListSyncJobs(ctx context.Context, filter entity.SyncJobFilter) ([]entity.SyncJobRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSyncJobs", ctx, filter)
+	ret0, _ := ret[0].([]entity.SyncJobRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSyncJobs indicates an expected call of ListSyncJobs.
+func (mr *MockJobRepositoryMockRecorder) ListSyncJobs(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSyncJobs", reflect.TypeOf((*MockJobRepository)(nil).ListSyncJobs), ctx, filter)
+}
+
+// SaveSyncCheckpoint mocks base method.
+func (m *MockJobRepository) SaveSyncCheckpoint(ctx context.Context, checkpoint entity.SyncCheckpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSyncCheckpoint", ctx, checkpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSyncCheckpoint indicates an expected call of SaveSyncCheckpoint.
+func (mr *MockJobRepositoryMockRecorder) SaveSyncCheckpoint(ctx, checkpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSyncCheckpoint", reflect.TypeOf((*MockJobRepository)(nil).SaveSyncCheckpoint), ctx, checkpoint)
+}
+
 // UpdateSyncJobRecord mocks base method.
-func (m *MockJobRepository) UpdateSyncJobRecord(ctx context.Context, jobID int64, status string, processed, succeeded, failed int, lastErr error, executionTime time.Duration) error {
+func (m *MockJobRepository) UpdateSyncJobRecord(ctx context.Context, jobID int64, status string, stats entity.SyncJobStats, lastErr error, executionTime time.Duration) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSyncJobRecord", ctx, jobID, status, processed, succeeded, failed, lastErr, executionTime)
+	ret := m.ctrl.Call(m, "UpdateSyncJobRecord", ctx, jobID, status, stats, lastErr, executionTime)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateSyncJobRecord indicates an expected call of UpdateSyncJobRecord.
-func (mr *MockJobRepositoryMockRecorder) UpdateSyncJobRecord(ctx, jobID, status, processed, succeeded, failed, lastErr, executionTime any) *gomock.Call {
+func (mr *MockJobRepositoryMockRecorder) UpdateSyncJobRecord(ctx, jobID, status, stats, lastErr, executionTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncJobRecord", reflect.TypeOf((*MockJobRepository)(nil).UpdateSyncJobRecord), ctx, jobID, status, stats, lastErr, executionTime)
+}
+
+// MockItemVersionRepository is a mock of ItemVersionRepository interface.
+type MockItemVersionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemVersionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockItemVersionRepositoryMockRecorder is the mock recorder for MockItemVersionRepository.
+type MockItemVersionRepositoryMockRecorder struct {
+	mock *MockItemVersionRepository
+}
+
+// NewMockItemVersionRepository creates a new mock instance.
+func NewMockItemVersionRepository(ctrl *gomock.Controller) *MockItemVersionRepository {
+	mock := &MockItemVersionRepository{ctrl: ctrl}
+	mock.recorder = &MockItemVersionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemVersionRepository) EXPECT() *MockItemVersionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountItemVersions mocks base method.
+func (m *MockItemVersionRepository) CountItemVersions(ctx context.Context, itemID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountItemVersions", ctx, itemID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountItemVersions indicates an expected call of CountItemVersions.
+func (mr *MockItemVersionRepositoryMockRecorder) CountItemVersions(ctx, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountItemVersions", reflect.TypeOf((*MockItemVersionRepository)(nil).CountItemVersions), ctx, itemID)
+}
+
+// FindItemVersion mocks base method.
+func (m *MockItemVersionRepository) FindItemVersion(ctx context.Context, itemID int, versionID int64) (entity.ItemVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindItemVersion", ctx, itemID, versionID)
+	ret0, _ := ret[0].(entity.ItemVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindItemVersion indicates an expected call of FindItemVersion.
+func (mr *MockItemVersionRepositoryMockRecorder) FindItemVersion(ctx, itemID, versionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindItemVersion", reflect.TypeOf((*MockItemVersionRepository)(nil).FindItemVersion), ctx, itemID, versionID)
+}
+
+// ListItemVersions mocks base method.
+func (m *MockItemVersionRepository) ListItemVersions(ctx context.Context, itemID, limit, offset int) ([]entity.ItemVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItemVersions", ctx, itemID, limit, offset)
+	ret0, _ := ret[0].([]entity.ItemVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItemVersions indicates an expected call of ListItemVersions.
+func (mr *MockItemVersionRepositoryMockRecorder) ListItemVersions(ctx, itemID, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncJobRecord", reflect.TypeOf((*MockJobRepository)(nil).UpdateSyncJobRecord), ctx, jobID, status, processed, succeeded, failed, lastErr, executionTime)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItemVersions", reflect.TypeOf((*MockItemVersionRepository)(nil).ListItemVersions), ctx, itemID, limit, offset)
+}
+
+// MockJobCanceller is a mock of JobCanceller interface.
+type MockJobCanceller struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobCancellerMockRecorder
+	isgomock struct{}
+}
+
+// MockJobCancellerMockRecorder is the mock recorder for MockJobCanceller.
+type MockJobCancellerMockRecorder struct {
+	mock *MockJobCanceller
+}
+
+// NewMockJobCanceller creates a new mock instance.
+func NewMockJobCanceller(ctrl *gomock.Controller) *MockJobCanceller {
+	mock := &MockJobCanceller{ctrl: ctrl}
+	mock.recorder = &MockJobCancellerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobCanceller) EXPECT() *MockJobCancellerMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockJobCanceller) Cancel(jobID int64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", jobID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockJobCancellerMockRecorder) Cancel(jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockJobCanceller)(nil).Cancel), jobID)
+}
+
+// MockRateLimitReporter is a mock of RateLimitReporter interface.
+type MockRateLimitReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockRateLimitReporterMockRecorder is the mock recorder for MockRateLimitReporter.
+type MockRateLimitReporterMockRecorder struct {
+	mock *MockRateLimitReporter
+}
+
+// NewMockRateLimitReporter creates a new mock instance.
+func NewMockRateLimitReporter(ctrl *gomock.Controller) *MockRateLimitReporter {
+	mock := &MockRateLimitReporter{ctrl: ctrl}
+	mock.recorder = &MockRateLimitReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitReporter) EXPECT() *MockRateLimitReporterMockRecorder {
+	return m.recorder
+}
+
+// States mocks base method.
+func (m *MockRateLimitReporter) States() []ratelimit.State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "States")
+	ret0, _ := ret[0].([]ratelimit.State)
+	return ret0
+}
+
+// States indicates an expected call of States.
+func (mr *MockRateLimitReporterMockRecorder) States() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "States", reflect.TypeOf((*MockRateLimitReporter)(nil).States))
+}
+
+// MockBreakerController is a mock of BreakerController interface.
+type MockBreakerController struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreakerControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockBreakerControllerMockRecorder is the mock recorder for MockBreakerController.
+type MockBreakerControllerMockRecorder struct {
+	mock *MockBreakerController
+}
+
+// NewMockBreakerController creates a new mock instance.
+func NewMockBreakerController(ctrl *gomock.Controller) *MockBreakerController {
+	mock := &MockBreakerController{ctrl: ctrl}
+	mock.recorder = &MockBreakerControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreakerController) EXPECT() *MockBreakerControllerMockRecorder {
+	return m.recorder
+}
+
+// ForceClose mocks base method.
+func (m *MockBreakerController) ForceClose(name string) (circuit.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceClose", name)
+	ret0, _ := ret[0].(circuit.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceClose indicates an expected call of ForceClose.
+func (mr *MockBreakerControllerMockRecorder) ForceClose(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceClose", reflect.TypeOf((*MockBreakerController)(nil).ForceClose), name)
+}
+
+// ForceOpen mocks base method.
+func (m *MockBreakerController) ForceOpen(name string) (circuit.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceOpen", name)
+	ret0, _ := ret[0].(circuit.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceOpen indicates an expected call of ForceOpen.
+func (mr *MockBreakerControllerMockRecorder) ForceOpen(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceOpen", reflect.TypeOf((*MockBreakerController)(nil).ForceOpen), name)
+}
+
+// Reset mocks base method.
+func (m *MockBreakerController) Reset(name string) (circuit.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", name)
+	ret0, _ := ret[0].(circuit.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockBreakerControllerMockRecorder) Reset(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockBreakerController)(nil).Reset), name)
+}
+
+// Snapshots mocks base method.
+func (m *MockBreakerController) Snapshots() []circuit.Snapshot {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshots")
+	ret0, _ := ret[0].([]circuit.Snapshot)
+	return ret0
+}
+
+// Snapshots indicates an expected call of Snapshots.
+func (mr *MockBreakerControllerMockRecorder) Snapshots() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshots", reflect.TypeOf((*MockBreakerController)(nil).Snapshots))
 }
 
 // MockItemRepository is a mock of ItemRepository interface.
@@ -487,6 +851,36 @@ func (mr *MockItemRepositoryMockRecorder) FindByType(ctx, itemType, limit, offse
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByType", reflect.TypeOf((*MockItemRepository)(nil).FindByType), ctx, itemType, limit, offset)
 }
 
+// FindDeleted mocks base method.
+func (m *MockItemRepository) FindDeleted(ctx context.Context, apiSource string, limit, offset int) ([]entity.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeleted", ctx, apiSource, limit, offset)
+	ret0, _ := ret[0].([]entity.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeleted indicates an expected call of FindDeleted.
+func (mr *MockItemRepositoryMockRecorder) FindDeleted(ctx, apiSource, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeleted", reflect.TypeOf((*MockItemRepository)(nil).FindDeleted), ctx, apiSource, limit, offset)
+}
+
+// ReconcileMissingItems mocks base method.
+func (m *MockItemRepository) ReconcileMissingItems(ctx context.Context, apiSource string, seenIDs []int, policy entity.DeletionPolicy) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileMissingItems", ctx, apiSource, seenIDs, policy)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileMissingItems indicates an expected call of ReconcileMissingItems.
+func (mr *MockItemRepositoryMockRecorder) ReconcileMissingItems(ctx, apiSource, seenIDs, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileMissingItems", reflect.TypeOf((*MockItemRepository)(nil).ReconcileMissingItems), ctx, apiSource, seenIDs, policy)
+}
+
 // Save mocks base method.
 func (m *MockItemRepository) Save(ctx context.Context, item entity.Item) error {
 	m.ctrl.T.Helper()
@@ -501,12 +895,28 @@ func (mr *MockItemRepositoryMockRecorder) Save(ctx, item any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockItemRepository)(nil).Save), ctx, item)
 }
 
+// UpsertManyWithHash mocks base method.
+func (m *MockItemRepository) UpsertManyWithHash(ctx context.Context, apiSource string, externalItems []entity.ExternalItem) ([]entity.UpsertResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertManyWithHash", ctx, apiSource, externalItems)
+	ret0, _ := ret[0].([]entity.UpsertResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertManyWithHash indicates an expected call of UpsertManyWithHash.
+func (mr *MockItemRepositoryMockRecorder) UpsertManyWithHash(ctx, apiSource, externalItems any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertManyWithHash", reflect.TypeOf((*MockItemRepository)(nil).UpsertManyWithHash), ctx, apiSource, externalItems)
+}
+
 // UpsertWithHash mocks base method.
-func (m *MockItemRepository) UpsertWithHash(ctx context.Context, apiSource string, externalItem entity.ExternalItem) error {
+func (m *MockItemRepository) UpsertWithHash(ctx context.Context, apiSource string, externalItem entity.ExternalItem) (entity.UpsertOutcome, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpsertWithHash", ctx, apiSource, externalItem)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(entity.UpsertOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpsertWithHash indicates an expected call of UpsertWithHash.
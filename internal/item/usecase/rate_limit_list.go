@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
+	"github.com/zainokta/item-sync/pkg/tracing"
+)
+
+type ListRateLimitsUseCase struct {
+	limiters RateLimitReporter
+	logger   logger.Logger
+}
+
+type ListRateLimitsResponse struct {
+	Limiters []ratelimit.State `json:"limiters"`
+}
+
+func NewListRateLimitsUseCase(limiters RateLimitReporter, logger logger.Logger) *ListRateLimitsUseCase {
+	return &ListRateLimitsUseCase{
+		limiters: limiters,
+		logger:   logger,
+	}
+}
+
+func (uc *ListRateLimitsUseCase) Execute(ctx context.Context) (ListRateLimitsResponse, error) {
+	ctx, span := tracing.Start(ctx, "ListRateLimitsUseCase.Execute")
+	defer span.End()
+
+	states := uc.limiters.States()
+	uc.logger.Debug("Listed outbound rate limiters", "count", len(states))
+
+	return ListRateLimitsResponse{
+		Limiters: states,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListRateLimitsUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReporter := mocks.NewMockRateLimitReporter(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	useCase := NewListRateLimitsUseCase(mockReporter, mockLogger)
+
+	blockedUntil := time.Now().Add(time.Minute)
+	states := []ratelimit.State{
+		{Name: "openweather-api", RequestsPerMinute: 60, Burst: 10, AvailableTokens: 10},
+		{Name: "pokemon-api", RequestsPerMinute: 100, Burst: 10, BlockedUntil: &blockedUntil},
+	}
+
+	mockReporter.EXPECT().States().Return(states)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	resp, err := useCase.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, states, resp.Limiters)
+}
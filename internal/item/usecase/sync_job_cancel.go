@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CancelSyncJobUseCase struct {
+	jobRepo   JobRepository
+	canceller JobCanceller
+	logger    logger.Logger
+}
+
+type CancelSyncJobRequest struct {
+	ID int64 `json:"id"`
+}
+
+type CancelSyncJobResponse struct {
+	JobID   int64  `json:"job_id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+func NewCancelSyncJobUseCase(jobRepo JobRepository, canceller JobCanceller, logger logger.Logger) *CancelSyncJobUseCase {
+	return &CancelSyncJobUseCase{
+		jobRepo:   jobRepo,
+		canceller: canceller,
+		logger:    logger,
+	}
+}
+
+func (uc *CancelSyncJobUseCase) Execute(ctx context.Context, req CancelSyncJobRequest) (CancelSyncJobResponse, error) {
+	ctx, span := tracing.Start(ctx, "CancelSyncJobUseCase.Execute", attribute.Int64("sync_job.id", req.ID))
+	defer span.End()
+
+	job, err := uc.jobRepo.FindSyncJobByID(ctx, req.ID)
+	if err != nil {
+		return CancelSyncJobResponse{}, err
+	}
+
+	if job.Status != entity.SyncJobStatusRunning {
+		return CancelSyncJobResponse{}, pkgErrors.SyncJobNotRunning(job.Status)
+	}
+
+	// A running record without a live job means it belongs to another process or was left behind by a crash
+	if !uc.canceller.Cancel(req.ID) {
+		uc.logger.Warn("Sync job is not running in this instance", "job_id", req.ID)
+		return CancelSyncJobResponse{}, pkgErrors.SyncJobNotRunning(job.Status).
+			WithDetail("reason", "job is not running in this instance")
+	}
+
+	uc.logger.Info("Sync job cancellation requested", "job_id", req.ID, "api_source", job.APISource)
+
+	return CancelSyncJobResponse{
+		JobID:   req.ID,
+		Status:  entity.SyncJobStatusCancelled,
+		Message: "Sync job cancellation has been requested",
+	}, nil
+}
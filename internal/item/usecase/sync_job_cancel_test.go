@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCancelSyncJobUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockCanceller := mocks.NewMockJobCanceller(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewCancelSyncJobUseCase(mockJobRepo, mockCanceller, mockLogger)
+
+	// Set expectations
+	mockJobRepo.EXPECT().
+		FindSyncJobByID(gomock.Any(), int64(7)).
+		Return(entity.SyncJobRecord{ID: 7, APISource: "pokemon", Status: entity.SyncJobStatusRunning}, nil)
+	mockCanceller.EXPECT().
+		Cancel(int64(7)).
+		Return(true)
+	mockLogger.EXPECT().
+		Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), CancelSyncJobRequest{ID: 7})
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), response.JobID)
+	assert.Equal(t, entity.SyncJobStatusCancelled, response.Status)
+}
+
+func TestCancelSyncJobUseCase_Execute_JobFinished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockCanceller := mocks.NewMockJobCanceller(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewCancelSyncJobUseCase(mockJobRepo, mockCanceller, mockLogger)
+
+	// Set expectations - completed jobs are never handed to the canceller
+	mockJobRepo.EXPECT().
+		FindSyncJobByID(gomock.Any(), int64(7)).
+		Return(entity.SyncJobRecord{ID: 7, Status: entity.SyncJobStatusCompleted}, nil)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), CancelSyncJobRequest{ID: 7})
+
+	// Assertions
+	require.Error(t, err)
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, pkgErrors.CategoryConflict, domainErr.Category)
+	assert.Equal(t, CancelSyncJobResponse{}, response)
+}
+
+func TestCancelSyncJobUseCase_Execute_NotRunningInInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockCanceller := mocks.NewMockJobCanceller(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewCancelSyncJobUseCase(mockJobRepo, mockCanceller, mockLogger)
+
+	// Set expectations
+	mockJobRepo.EXPECT().
+		FindSyncJobByID(gomock.Any(), int64(7)).
+		Return(entity.SyncJobRecord{ID: 7, Status: entity.SyncJobStatusRunning}, nil)
+	mockCanceller.EXPECT().
+		Cancel(int64(7)).
+		Return(false)
+	mockLogger.EXPECT().
+		Warn("Sync job is not running in this instance", "job_id", int64(7))
+
+	// Execute test
+	_, err := useCase.Execute(context.Background(), CancelSyncJobRequest{ID: 7})
+
+	// Assertions
+	require.Error(t, err)
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "SYNC_JOB_NOT_RUNNING", domainErr.Code)
+}
+
+func TestCancelSyncJobUseCase_Execute_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockCanceller := mocks.NewMockJobCanceller(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewCancelSyncJobUseCase(mockJobRepo, mockCanceller, mockLogger)
+
+	// Set expectations
+	mockJobRepo.EXPECT().
+		FindSyncJobByID(gomock.Any(), int64(404)).
+		Return(entity.SyncJobRecord{}, pkgErrors.SyncJobNotFound())
+
+	// Execute test
+	_, err := useCase.Execute(context.Background(), CancelSyncJobRequest{ID: 404})
+
+	// Assertions
+	require.Error(t, err)
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type FetchSyncJobUseCase struct {
+	jobRepo JobRepository
+	logger  logger.Logger
+}
+
+type FetchSyncJobRequest struct {
+	ID int64 `json:"id"`
+}
+
+type FetchSyncJobResponse struct {
+	Job entity.SyncJobRecord `json:"job"`
+}
+
+func NewFetchSyncJobUseCase(jobRepo JobRepository, logger logger.Logger) *FetchSyncJobUseCase {
+	return &FetchSyncJobUseCase{
+		jobRepo: jobRepo,
+		logger:  logger,
+	}
+}
+
+func (uc *FetchSyncJobUseCase) Execute(ctx context.Context, req FetchSyncJobRequest) (FetchSyncJobResponse, error) {
+	ctx, span := tracing.Start(ctx, "FetchSyncJobUseCase.Execute", attribute.Int64("sync_job.id", req.ID))
+	defer span.End()
+
+	job, err := uc.jobRepo.FindSyncJobByID(ctx, req.ID)
+	if err != nil {
+		return FetchSyncJobResponse{}, err
+	}
+
+	return FetchSyncJobResponse{
+		Job: job,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFetchSyncJobUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewFetchSyncJobUseCase(mockJobRepo, mockLogger)
+
+	// Mock data
+	mockJob := entity.SyncJobRecord{
+		ID:              42,
+		JobName:         "manual_sync",
+		APISource:       "pokemon",
+		Status:          entity.SyncJobStatusFailed,
+		ItemsProcessed:  20,
+		ItemsSucceeded:  18,
+		ItemsFailed:     2,
+		ErrorMessage:    "database error",
+		ExecutionTimeMs: 1500,
+	}
+
+	// Set expectations
+	mockJobRepo.EXPECT().
+		FindSyncJobByID(gomock.Any(), int64(42)).
+		Return(mockJob, nil)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), FetchSyncJobRequest{ID: 42})
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Equal(t, mockJob, response.Job)
+}
+
+func TestFetchSyncJobUseCase_Execute_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewFetchSyncJobUseCase(mockJobRepo, mockLogger)
+
+	// Set expectations
+	mockJobRepo.EXPECT().
+		FindSyncJobByID(gomock.Any(), int64(404)).
+		Return(entity.SyncJobRecord{}, pkgErrors.SyncJobNotFound())
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), FetchSyncJobRequest{ID: 404})
+
+	// Assertions
+	require.Error(t, err)
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "SYNC_JOB_NOT_FOUND", domainErr.Code)
+	assert.Equal(t, FetchSyncJobResponse{}, response)
+}
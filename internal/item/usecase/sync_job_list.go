@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/tracing"
+)
+
+type ListSyncJobsUseCase struct {
+	jobRepo JobRepository
+	logger  logger.Logger
+}
+
+func NewListSyncJobsUseCase(jobRepo JobRepository, logger logger.Logger) *ListSyncJobsUseCase {
+	return &ListSyncJobsUseCase{
+		jobRepo: jobRepo,
+		logger:  logger,
+	}
+}
+
+type ListSyncJobsRequest struct {
+	APISource string    `json:"api_source"`
+	Status    string    `json:"status"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Limit     int       `json:"limit"`
+	Offset    int       `json:"offset"`
+}
+
+type ListSyncJobsResponse struct {
+	Jobs       []entity.SyncJobRecord `json:"jobs"`
+	TotalCount int                    `json:"total_count"`
+}
+
+func (uc *ListSyncJobsUseCase) Execute(ctx context.Context, req ListSyncJobsRequest) (ListSyncJobsResponse, error) {
+	ctx, span := tracing.Start(ctx, "ListSyncJobsUseCase.Execute")
+	defer span.End()
+
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	if !req.From.IsZero() && !req.To.IsZero() && req.From.After(req.To) {
+		return ListSyncJobsResponse{}, errors.InvalidQuery("from must not be after to")
+	}
+
+	filter := entity.SyncJobFilter{
+		APISource:     req.APISource,
+		Status:        req.Status,
+		StartedAfter:  req.From,
+		StartedBefore: req.To,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	}
+
+	jobs, err := uc.jobRepo.ListSyncJobs(ctx, filter)
+	if err != nil {
+		return ListSyncJobsResponse{}, err
+	}
+
+	total, err := uc.jobRepo.CountSyncJobs(ctx, filter)
+	if err != nil {
+		return ListSyncJobsResponse{}, err
+	}
+
+	if jobs == nil {
+		jobs = []entity.SyncJobRecord{}
+	}
+
+	return ListSyncJobsResponse{
+		Jobs:       jobs,
+		TotalCount: total,
+	}, nil
+}
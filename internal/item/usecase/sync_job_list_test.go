@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/internal/item/usecase/mocks"
+	loggermocks "github.com/zainokta/item-sync/pkg/logger/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListSyncJobsUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewListSyncJobsUseCase(mockJobRepo, mockLogger)
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	// Setup request
+	request := ListSyncJobsRequest{
+		APISource: "pokemon",
+		Status:    entity.SyncJobStatusCompleted,
+		From:      from,
+		To:        to,
+		Limit:     10,
+	}
+
+	// Mock data
+	mockJobs := []entity.SyncJobRecord{
+		{ID: 2, JobName: "manual_sync", APISource: "pokemon", Status: entity.SyncJobStatusCompleted, ItemsProcessed: 20},
+		{ID: 1, JobName: "background-sync", APISource: "pokemon", Status: entity.SyncJobStatusCompleted, ItemsProcessed: 1302},
+	}
+
+	// Set expectations
+	filter := entity.SyncJobFilter{
+		APISource:     "pokemon",
+		Status:        entity.SyncJobStatusCompleted,
+		StartedAfter:  from,
+		StartedBefore: to,
+		Limit:         10,
+		Offset:        0,
+	}
+	mockJobRepo.EXPECT().
+		ListSyncJobs(gomock.Any(), filter).
+		Return(mockJobs, nil)
+	mockJobRepo.EXPECT().
+		CountSyncJobs(gomock.Any(), filter).
+		Return(12, nil)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), request)
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Len(t, response.Jobs, 2)
+	assert.Equal(t, 12, response.TotalCount)
+	assert.Equal(t, int64(2), response.Jobs[0].ID)
+}
+
+func TestListSyncJobsUseCase_Execute_DefaultPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewListSyncJobsUseCase(mockJobRepo, mockLogger)
+
+	// Set expectations - defaults applied, nil result normalised to empty slice
+	mockJobRepo.EXPECT().
+		ListSyncJobs(gomock.Any(), entity.SyncJobFilter{Limit: 20, Offset: 0}).
+		Return(nil, nil)
+	mockJobRepo.EXPECT().
+		CountSyncJobs(gomock.Any(), entity.SyncJobFilter{Limit: 20, Offset: 0}).
+		Return(0, nil)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), ListSyncJobsRequest{Offset: -5})
+
+	// Assertions
+	require.NoError(t, err)
+	assert.NotNil(t, response.Jobs)
+	assert.Empty(t, response.Jobs)
+	assert.Equal(t, 0, response.TotalCount)
+}
+
+func TestListSyncJobsUseCase_Execute_InvalidTimeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewListSyncJobsUseCase(mockJobRepo, mockLogger)
+
+	// Setup request with from after to
+	request := ListSyncJobsRequest{
+		From: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), request)
+
+	// Assertions
+	var domainErr *pkgErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "INVALID_QUERY", domainErr.Code)
+	assert.Equal(t, ListSyncJobsResponse{}, response)
+}
+
+func TestListSyncJobsUseCase_Execute_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewListSyncJobsUseCase(mockJobRepo, mockLogger)
+
+	// Set expectations
+	mockJobRepo.EXPECT().
+		ListSyncJobs(gomock.Any(), gomock.Any()).
+		Return(nil, assert.AnError)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), ListSyncJobsRequest{})
+
+	// Assertions
+	require.Error(t, err)
+	assert.Equal(t, ListSyncJobsResponse{}, response)
+}
+
+func TestListSyncJobsUseCase_Execute_CountError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup mocks
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockLogger := loggermocks.NewMockLogger(ctrl)
+
+	// Create usecase
+	useCase := NewListSyncJobsUseCase(mockJobRepo, mockLogger)
+
+	// Set expectations
+	mockJobRepo.EXPECT().
+		ListSyncJobs(gomock.Any(), gomock.Any()).
+		Return([]entity.SyncJobRecord{{ID: 1}}, nil)
+	mockJobRepo.EXPECT().
+		CountSyncJobs(gomock.Any(), gomock.Any()).
+		Return(0, assert.AnError)
+
+	// Execute test
+	response, err := useCase.Execute(context.Background(), ListSyncJobsRequest{})
+
+	// Assertions
+	require.Error(t, err)
+	assert.Equal(t, ListSyncJobsResponse{}, response)
+}
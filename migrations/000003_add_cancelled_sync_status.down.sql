@@ -0,0 +1,5 @@
+-- Cancelled jobs did not finish, record them as failed before shrinking the enum
+UPDATE sync_jobs SET status = 'failed' WHERE status = 'cancelled';
+
+ALTER TABLE sync_jobs
+MODIFY COLUMN status ENUM('running', 'completed', 'failed') NOT NULL DEFAULT 'running';
@@ -0,0 +1,2 @@
+ALTER TABLE sync_jobs
+MODIFY COLUMN status ENUM('running', 'completed', 'failed', 'cancelled') NOT NULL DEFAULT 'running';
@@ -0,0 +1,4 @@
+ALTER TABLE sync_jobs
+DROP COLUMN items_unchanged,
+DROP COLUMN items_updated,
+DROP COLUMN items_inserted;
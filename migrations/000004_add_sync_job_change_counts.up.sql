@@ -0,0 +1,4 @@
+ALTER TABLE sync_jobs
+ADD COLUMN items_inserted INT NOT NULL DEFAULT 0 AFTER items_failed,
+ADD COLUMN items_updated INT NOT NULL DEFAULT 0 AFTER items_inserted,
+ADD COLUMN items_unchanged INT NOT NULL DEFAULT 0 AFTER items_updated;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS item_versions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id INT NOT NULL,
+    sync_job_id INT NULL,
+    previous_hash VARCHAR(64) NOT NULL DEFAULT '',
+    content_hash VARCHAR(64) NOT NULL,
+    old_payload JSON NULL,
+    new_payload JSON NOT NULL,
+    created_at TIMESTAMP(6) DEFAULT CURRENT_TIMESTAMP(6),
+
+    INDEX idx_item_versions_item (item_id, id),
+    INDEX idx_item_versions_sync_job (sync_job_id),
+
+    CONSTRAINT fk_item_versions_item FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,5 @@
+DROP INDEX idx_api_source_deleted_at ON items;
+
+ALTER TABLE items
+DROP COLUMN stale_at,
+DROP COLUMN deleted_at;
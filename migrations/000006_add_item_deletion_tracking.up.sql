@@ -0,0 +1,5 @@
+ALTER TABLE items
+ADD COLUMN deleted_at TIMESTAMP NULL,
+ADD COLUMN stale_at TIMESTAMP NULL;
+
+CREATE INDEX idx_api_source_deleted_at ON items(api_source, deleted_at);
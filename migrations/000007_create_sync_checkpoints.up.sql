@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS sync_checkpoints (
+    api_source VARCHAR(100) NOT NULL PRIMARY KEY,
+    cursor_offset INT NOT NULL DEFAULT 0,
+    next_url TEXT,
+    cursor VARCHAR(1024) NOT NULL DEFAULT '',
+    watermark TIMESTAMP NULL,
+    sync_job_id INT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+ALTER TABLE sync_jobs
+DROP COLUMN pages_not_modified;
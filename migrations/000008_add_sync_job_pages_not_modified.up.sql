@@ -0,0 +1,2 @@
+ALTER TABLE sync_jobs
+ADD COLUMN pages_not_modified INT NOT NULL DEFAULT 0 AFTER items_unchanged;
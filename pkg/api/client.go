@@ -4,14 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/zainokta/item-sync/config"
 	"github.com/zainokta/item-sync/internal/item/entity"
 	"github.com/zainokta/item-sync/pkg/circuit"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/metrics"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
 	"github.com/zainokta/item-sync/pkg/retry"
+	"github.com/zainokta/item-sync/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ExternalAPIClient interface {
@@ -21,63 +33,130 @@ type ExternalAPIClient interface {
 }
 
 var (
-	once     sync.Once
-	instance *BaseClient
+	// Every client calling an upstream shares its limiter and breaker, whichever process component built the client
+	rateLimiters = ratelimit.NewManager()
+	breakers     = circuit.NewBreakerManager(config.RetryConfig{}, nil).WithClassifier(countsAgainstBreaker) // clients always pass their own settings, the server sets the logger
+
+	httpClientsMu sync.Mutex
+	httpClients   = make(map[httpClientKey]*http.Client)
 )
 
+// RateLimiters returns the outbound rate limiters of all external API clients
+func RateLimiters() *ratelimit.Manager {
+	return rateLimiters
+}
+
+// Breakers returns the circuit breakers of all external API clients
+func Breakers() *circuit.BreakerManager {
+	return breakers
+}
+
 type BaseClient struct {
-	client         *http.Client
-	retrier        *retry.Retrier
-	breakerManager *circuit.BreakerManager
-	logger         logger.Logger
+	client       *http.Client
+	config       config.APIConfig
+	retryConfig  config.RetryConfig
+	retrier      *retry.Retrier
+	breakers     *circuit.BreakerManager
+	rateLimiters *ratelimit.Manager
+	responses    ResponseCache // nil when conditional requests are disabled
+	logger       logger.Logger
+}
+
+// newBaseClient builds the shared plumbing of a client from the settings of its provider, see config.APIConfig.ForSource
+func newBaseClient(config config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) *BaseClient {
+	bc := &BaseClient{
+		client:       httpClientFor(config, logger),
+		config:       config,
+		retryConfig:  retryConfig,
+		retrier:      retry.New(retryConfig, logger),
+		breakers:     breakers,
+		rateLimiters: rateLimiters,
+		logger:       logger,
+	}
+	if config.ConditionalRequests {
+		bc.responses = sharedResponseCache()
+	}
+	return bc
+}
+
+// httpClientKey holds the settings that make two providers need separate HTTP clients
+type httpClientKey struct {
+	timeout             time.Duration
+	maxIdleConns        int
+	idleConnTimeout     time.Duration
+	disableCompression  bool
+	maxIdleConnsPerHost int
+	proxyURL            string
 }
 
-func getBaseClient(config config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) *BaseClient {
-	retrier := retry.New(retryConfig, logger)
-	breakerManager := circuit.NewBreakerManager(retryConfig, logger)
-
-	once.Do(func() {
-		instance = &BaseClient{
-			client: &http.Client{
-				Timeout: config.Timeout,
-				Transport: &http.Transport{
-					MaxIdleConns:        config.MaxIdleConns,
-					IdleConnTimeout:     config.IdleConnTimeout,
-					DisableCompression:  config.DisableCompression,
-					MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
-				},
-			},
-			retrier:        retrier,
-			breakerManager: breakerManager,
-			logger:         logger,
+// httpClientFor returns the HTTP client for the given settings. Clients are reused so that building an API client
+// per request does not leak connection pools.
+func httpClientFor(config config.APIConfig, logger logger.Logger) *http.Client {
+	key := httpClientKey{
+		timeout:             config.Timeout,
+		maxIdleConns:        config.MaxIdleConns,
+		idleConnTimeout:     config.IdleConnTimeout,
+		disableCompression:  config.DisableCompression,
+		maxIdleConnsPerHost: config.MaxIdleConnsPerHost,
+		proxyURL:            config.ProxyURL,
+	}
+
+	httpClientsMu.Lock()
+	defer httpClientsMu.Unlock()
+
+	if client, exists := httpClients[key]; exists {
+		return client
+	}
+
+	transport := &http.Transport{
+		MaxIdleConns:        config.MaxIdleConns,
+		IdleConnTimeout:     config.IdleConnTimeout,
+		DisableCompression:  config.DisableCompression,
+		MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
+	}
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			logger.Warn("Ignoring invalid API proxy URL", "proxy_url", config.ProxyURL, "error", err)
+		} else {
+			transport.Proxy = http.ProxyURL(proxyURL)
 		}
-	})
+	}
 
-	return instance
+	client := &http.Client{
+		Timeout:   config.Timeout,
+		Transport: transport,
+	}
+	httpClients[key] = client
+	return client
 }
 
-func NewAPIClient(apiType string, config config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (ExternalAPIClient, error) {
-	switch apiType {
-	case "pokemon":
-		return NewPokemonClient(config, retryConfig, logger), nil
-	case "openweather":
-		return NewOpenWeatherClient(config, retryConfig, logger), nil
-	default:
-		return nil, fmt.Errorf("unsupported API type: %s", apiType)
+// baseURLOrDefault returns the configured base URL without trailing slash, or the fallback when none is configured
+func baseURLOrDefault(configured, fallback string) string {
+	if configured == "" {
+		return fallback
 	}
+	return strings.TrimRight(configured, "/")
+}
+
+// breaker returns the circuit breaker of the named upstream
+func (bc *BaseClient) breaker(name string) *circuit.CircuitBreaker {
+	return bc.breakers.GetBreakerWithConfig(name, bc.retryConfig, bc.logger)
+}
+
+// limiter returns the outbound rate limiter of the named upstream
+func (bc *BaseClient) limiter(name string) *ratelimit.Limiter {
+	return bc.rateLimiters.GetLimiter(name, bc.config.RateLimit, bc.config.RateLimitBurst)
 }
 
-func (bc *BaseClient) doRequest(req *http.Request, result interface{}) error {
-	resp, err := bc.client.Do(req)
+// doRequest sends the request once the limiter allows it and feeds the response rate limit headers back to it
+func (bc *BaseClient) doRequest(limiter *ratelimit.Limiter, req *http.Request, result interface{}) error {
+	resp, err := bc.roundTrip(limiter, req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-	}
-
 	if result != nil {
 		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
 			return fmt.Errorf("failed to decode response: %w", err)
@@ -87,21 +166,50 @@ func (bc *BaseClient) doRequest(req *http.Request, result interface{}) error {
 	return nil
 }
 
-func shouldNotRetry(err error) bool {
-	if err == context.Canceled || err == context.DeadlineExceeded {
-		return true
+// roundTrip sends the request through the limiter and returns the response of a 2xx answer, or of a 304 answer to a
+// conditional request. Other answers are returned as *HTTPError. Every call is one attempt, traced in its own span.
+func (bc *BaseClient) roundTrip(limiter *ratelimit.Limiter, req *http.Request) (resp *http.Response, err error) {
+	// The query is left out, it may carry API keys
+	ctx, span := tracing.StartWithKind(req.Context(), req.Method+" "+limiter.Name(), trace.SpanKindClient,
+		attribute.String("upstream", limiter.Name()),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+		semconv.URLPath(req.URL.Path))
+	defer func() { tracing.End(span, err) }()
+
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	waitStart := time.Now()
+	if err := limiter.Wait(ctx); err != nil {
+		return nil, err
 	}
+	span.SetAttributes(attribute.Int64("ratelimit.wait_ms", time.Since(waitStart).Milliseconds()))
 
-	errStr := err.Error()
+	start := time.Now()
+	resp, err = bc.client.Do(req)
+	if err != nil {
+		metrics.ObserveUpstreamRequest(limiter.Name(), 0, time.Since(start))
+		return nil, err
+	}
+	metrics.ObserveUpstreamRequest(limiter.Name(), resp.StatusCode, time.Since(start))
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 
-	if len(errStr) >= 8 && errStr[:4] == "HTTP" {
-		if len(errStr) >= 8 {
-			statusStr := errStr[5:8]
-			if statusStr[0] == '4' {
-				return statusStr != "408" && statusStr != "429"
-			}
+	limiter.Observe(resp.StatusCode, resp.Header)
+
+	if resp.StatusCode == http.StatusNotModified && isConditional(req) {
+		return resp, nil
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return nil, &HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header,
+			Body:       string(body),
 		}
 	}
 
-	return false
+	return resp, nil
 }
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pkgErrors "github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/pkg/metrics"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
+	"github.com/zainokta/item-sync/pkg/retry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestBaseClient_doRequest_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(strings.Repeat("x", 2*maxErrorBodySize)))
+	}))
+	defer server.Close()
+
+	client := &BaseClient{client: server.Client()}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	throttled := testutil.ToFloat64(metrics.UpstreamRequests.WithLabelValues("test", "429"))
+
+	err = client.doRequest(ratelimit.NewLimiter("test", 0, 1), req, nil)
+
+	assert.Equal(t, throttled+1, testutil.ToFloat64(metrics.UpstreamRequests.WithLabelValues("test", "429")), "The attempt should be counted by upstream and status")
+
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	assert.Equal(t, "7", httpErr.Header.Get("Retry-After"))
+	assert.Len(t, httpErr.Body, maxErrorBodySize, "Body should be truncated")
+	assert.Equal(t, "HTTP 429: 429 Too Many Requests", httpErr.Error())
+
+	delay, ok := httpErr.RetryAfter()
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, delay)
+}
+
+func TestBaseClient_roundTrip_Span(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &BaseClient{client: server.Client()}
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/items?api_key=secret", nil)
+	require.NoError(t, err)
+
+	err = client.doRequest(ratelimit.NewLimiter("test", 0, 1), req, nil)
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET test", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Contains(t, traceparent, span.SpanContext.SpanID().String(), "The attempt span should be propagated upstream")
+
+	for _, attr := range span.Attributes {
+		assert.NotContains(t, attr.Value.Emit(), "secret", "The query should not be recorded")
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	throttled := &HTTPError{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"3"}}}
+
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+		after     time.Duration
+	}{
+		{name: "not found", err: &HTTPError{StatusCode: http.StatusNotFound}, retryable: false},
+		{name: "unauthorized", err: &HTTPError{StatusCode: http.StatusUnauthorized}, retryable: false},
+		{name: "request timeout", err: &HTTPError{StatusCode: http.StatusRequestTimeout}, retryable: true},
+		{name: "throttled with Retry-After", err: throttled, retryable: true, after: 3 * time.Second},
+		{name: "throttled without Retry-After", err: &HTTPError{StatusCode: http.StatusTooManyRequests}, retryable: true},
+		{name: "server error", err: &HTTPError{StatusCode: http.StatusBadGateway}, retryable: true},
+		{name: "wrapped client error", err: fmt.Errorf("page 3: %w", &HTTPError{StatusCode: http.StatusBadRequest}), retryable: false},
+		{name: "network error", err: errors.New("connection refused"), retryable: true},
+		{name: "cancelled", err: context.Canceled, retryable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyError(tt.err)
+
+			assert.Equal(t, tt.retryable, retry.IsRetryable(err))
+			assert.ErrorIs(t, err, tt.err)
+
+			var retryableErr retry.RetryableError
+			if errors.As(err, &retryableErr) {
+				assert.Equal(t, tt.after, retryableErr.After)
+			}
+		})
+	}
+}
+
+func TestCountsAgainstBreaker(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		counts bool
+	}{
+		{name: "server error", err: &HTTPError{StatusCode: http.StatusServiceUnavailable}, counts: true},
+		{name: "not found", err: &HTTPError{StatusCode: http.StatusNotFound}, counts: false},
+		{name: "throttled", err: retry.NewRetryableError(&HTTPError{StatusCode: http.StatusTooManyRequests}), counts: false},
+		{name: "network error", err: errors.New("connection refused"), counts: true},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, counts: true},
+		{name: "cancelled", err: fmt.Errorf("page 2: %w", context.Canceled), counts: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.counts, countsAgainstBreaker(tt.err))
+		})
+	}
+}
+
+func TestExternalAPIFailed_UpstreamStatus(t *testing.T) {
+	err := pkgErrors.ExternalAPIFailed(retry.NewNonRetryableError(&HTTPError{StatusCode: http.StatusNotFound}))
+	assert.Equal(t, http.StatusNotFound, err.Details["upstream_status"])
+
+	err = pkgErrors.ExternalAPIFailed(errors.New("connection refused"))
+	assert.Nil(t, err.Details)
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zainokta/item-sync/pkg/ratelimit"
+)
+
+// maxCachedBodySize is the largest response body kept for conditional requests, larger pages are always refetched
+const maxCachedBodySize = 1 << 20
+
+// CachedResponse is what a conditional request keeps of the last full answer for a URL. A 304 carries no body, so
+// the body is kept along with the validators for callers to still read the page and follow its pagination.
+type CachedResponse struct {
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"last_modified,omitempty"`
+	Body         json.RawMessage `json:"body"`
+}
+
+// ResponseCache stores the CachedResponse of listing page URLs
+type ResponseCache interface {
+	// GetResponse returns nil without error when the URL has no cached response
+	GetResponse(ctx context.Context, url string) (*CachedResponse, error)
+	SetResponse(ctx context.Context, url string, response CachedResponse, ttl time.Duration) error
+}
+
+var (
+	responseCacheMu sync.RWMutex
+	responseCache   ResponseCache
+)
+
+// UseResponseCache makes the clients built afterwards send conditional requests for listing pages through the cache,
+// nil turns conditional requests off
+func UseResponseCache(cache ResponseCache) {
+	responseCacheMu.Lock()
+	defer responseCacheMu.Unlock()
+	responseCache = cache
+}
+
+func sharedResponseCache() ResponseCache {
+	responseCacheMu.RLock()
+	defer responseCacheMu.RUnlock()
+	return responseCache
+}
+
+// Validators are the ETag, Last-Modified and body a listing page was answered with. They are only stored once
+// Commit is called, so that a page whose items were not stored is fetched in full again on the next pass.
+type Validators struct {
+	cache ResponseCache
+	url   string
+	entry CachedResponse
+	ttl   time.Duration
+}
+
+// Commit stores the validators for the next conditional request of the page, it does nothing on nil validators
+func (v *Validators) Commit(ctx context.Context) error {
+	if v == nil {
+		return nil
+	}
+	return v.cache.SetResponse(ctx, v.url, v.entry, v.ttl)
+}
+
+// LastModified returns the Last-Modified the page was answered with, zero on nil validators or when the upstream
+// sent none or an unparsable one
+func (v *Validators) LastModified() time.Time {
+	if v == nil || v.entry.LastModified == "" {
+		return time.Time{}
+	}
+	lastModified, err := http.ParseTime(v.entry.LastModified)
+	if err != nil {
+		return time.Time{}
+	}
+	return lastModified
+}
+
+type unconditionalKey struct{}
+
+// WithoutConditionalRequests makes the listing requests sent with ctx unconditional, for passes whose stored items
+// cannot be trusted to match the cached validators
+func WithoutConditionalRequests(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unconditionalKey{}, true)
+}
+
+func conditionalRequestsAllowed(ctx context.Context) bool {
+	unconditional, _ := ctx.Value(unconditionalKey{}).(bool)
+	return !unconditional
+}
+
+// isConditional reports whether the request carries validators, so that a 304 answer is expected
+func isConditional(req *http.Request) bool {
+	return req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != ""
+}
+
+// doConditionalRequest is doRequest for GETs of listing pages. When the URL was fetched before it sends the stored
+// ETag and Last-Modified as If-None-Match and If-Modified-Since; on a 304 the stored body is decoded into result and
+// notModified is set. A full answer returns its validators, which the caller commits once the page is stored. Cache
+// failures only cost the conditional request, never the request itself.
+func (bc *BaseClient) doConditionalRequest(limiter *ratelimit.Limiter, req *http.Request, result interface{}) (notModified bool, validators *Validators, err error) {
+	if bc.responses == nil || req.Method != http.MethodGet || result == nil {
+		return false, nil, bc.doRequest(limiter, req, result)
+	}
+
+	ctx := req.Context()
+	key := req.URL.String()
+
+	var cached *CachedResponse
+	if conditionalRequestsAllowed(ctx) {
+		cached, err = bc.responses.GetResponse(ctx, key)
+		if err != nil {
+			bc.logger.Warn("Failed to load cached response, sending an unconditional request", "url", key, "error", err)
+			cached = nil
+		}
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := bc.roundTrip(limiter, req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		if err := json.Unmarshal(cached.Body, result); err != nil {
+			return false, nil, fmt.Errorf("failed to decode cached response: %w", err)
+		}
+		return true, nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return false, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	entry := CachedResponse{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         body,
+	}
+	if (entry.ETag != "" || entry.LastModified != "") && len(body) <= maxCachedBodySize {
+		validators = &Validators{cache: bc.responses, url: key, entry: entry, ttl: bc.config.ConditionalCacheTTL}
+	}
+
+	return false, validators, nil
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
+)
+
+type memoryResponseCache struct {
+	mu        sync.Mutex
+	responses map[string]CachedResponse
+}
+
+func (c *memoryResponseCache) GetResponse(ctx context.Context, url string) (*CachedResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	response, ok := c.responses[url]
+	if !ok {
+		return nil, nil
+	}
+	return &response, nil
+}
+
+func (c *memoryResponseCache) SetResponse(ctx context.Context, url string, response CachedResponse, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[url] = response
+	return nil
+}
+
+func TestBaseClient_doConditionalRequest(t *testing.T) {
+	var conditionalHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditionalHeaders = append(conditionalHeaders, r.Header.Get("If-None-Match")+"|"+r.Header.Get("If-Modified-Since"))
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 12 Oct 2026 08:00:00 GMT")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"name":"bulbasaur"}`))
+	}))
+	defer server.Close()
+
+	cache := &memoryResponseCache{responses: make(map[string]CachedResponse)}
+	client := &BaseClient{
+		client:    server.Client(),
+		config:    config.APIConfig{ConditionalCacheTTL: time.Hour},
+		responses: cache,
+		logger:    logger.NewLogger(logger.LevelError, "test"),
+	}
+	limiter := ratelimit.NewLimiter("test", 0, 1)
+
+	fetch := func(ctx context.Context) (bool, *Validators, string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/pokemon?offset=0", nil)
+		require.NoError(t, err)
+		var result struct {
+			Name string `json:"name"`
+		}
+		notModified, validators, err := client.doConditionalRequest(limiter, req, &result)
+		require.NoError(t, err)
+		return notModified, validators, result.Name
+	}
+
+	notModified, validators, name := fetch(context.Background())
+	assert.False(t, notModified)
+	assert.Equal(t, "bulbasaur", name)
+	require.NotNil(t, validators)
+	assert.Equal(t, time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC), validators.LastModified())
+
+	// Validators that were not committed are not sent
+	notModified, validators, _ = fetch(context.Background())
+	assert.False(t, notModified, "Validators should only be used once committed")
+	require.NoError(t, validators.Commit(context.Background()))
+
+	notModified, validators, name = fetch(context.Background())
+	assert.True(t, notModified, "The request after the commit should be answered with 304")
+	assert.Nil(t, validators)
+	assert.True(t, validators.LastModified().IsZero())
+	assert.Equal(t, "bulbasaur", name, "A 304 should be decoded from the cached body")
+
+	notModified, _, _ = fetch(WithoutConditionalRequests(context.Background()))
+	assert.False(t, notModified, "Unconditional passes should not send the validators")
+
+	assert.Equal(t, []string{"|", "|", `"v1"|Mon, 12 Oct 2026 08:00:00 GMT`, "|"}, conditionalHeaders)
+}
+
+func TestBaseClient_doRequest_UnexpectedNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	client := &BaseClient{client: server.Client()}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	err = client.doRequest(ratelimit.NewLimiter("test", 0, 1), req, nil)
+
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr, "A 304 to an unconditional request has no body to decode")
+	assert.Equal(t, http.StatusNotModified, httpErr.StatusCode)
+}
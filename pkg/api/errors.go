@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/zainokta/item-sync/pkg/circuit"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
+	"github.com/zainokta/item-sync/pkg/retry"
+)
+
+// maxErrorBodySize is how much of a failed response body an HTTPError keeps
+const maxErrorBodySize = 1024
+
+// HTTPError is returned for upstream responses outside the 2xx range
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       string // truncated to maxErrorBodySize bytes
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
+}
+
+// HTTPStatusCode lets callers outside this package read the upstream status without importing it
+func (e *HTTPError) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+// Temporary reports whether the same request may succeed later: timeouts, throttling and server errors
+func (e *HTTPError) Temporary() bool {
+	switch {
+	case e.StatusCode == http.StatusRequestTimeout, e.StatusCode == http.StatusTooManyRequests:
+		return true
+	case e.StatusCode >= http.StatusInternalServerError:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryAfter returns the delay a 429 or 503 response asked for
+func (e *HTTPError) RetryAfter() (time.Duration, bool) {
+	if e.StatusCode != http.StatusTooManyRequests && e.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	return ratelimit.ParseRetryAfter(e.Header.Get("Retry-After"), time.Now())
+}
+
+// classifyError wraps a failed request attempt for the retrier, throttled responses are retried after their Retry-After
+func classifyError(err error) error {
+	if shouldNotRetry(err) {
+		return retry.NewNonRetryableError(err)
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		if delay, ok := httpErr.RetryAfter(); ok {
+			return retry.NewRetryableErrorAfter(err, delay)
+		}
+	}
+	return retry.NewRetryableError(err)
+}
+
+// countsAgainstBreaker is the classifier of the API breakers: cancelled calls and 4xx responses say nothing about the
+// upstream health, throttling is left to the rate limiters
+func countsAgainstBreaker(err error) bool {
+	if !circuit.DefaultClassifier(err) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode < http.StatusBadRequest || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+func shouldNotRetry(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return !httpErr.Temporary()
+	}
+
+	return false
+}
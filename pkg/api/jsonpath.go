@@ -0,0 +1,91 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lookupPath resolves a JSONPath-style expression against decoded JSON.
+// Supported syntax is the subset needed for field mappings: an optional
+// leading "$", dotted field names and numeric indexes, e.g. "$.data[0].id".
+func lookupPath(data interface{}, path string) (interface{}, bool) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	current := data
+	for _, segment := range segments {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if segment.isIndex {
+				return nil, false
+			}
+			value, ok := node[segment.field]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			if !segment.isIndex || segment.index < 0 || segment.index >= len(node) {
+				return nil, false
+			}
+			current = node[segment.index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+type pathSegment struct {
+	field   string
+	index   int
+	isIndex bool
+}
+
+func parsePath(path string) ([]pathSegment, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, ".")
+
+	var segments []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			continue
+		}
+
+		field := part
+		var indexes []string
+		if open := strings.Index(part, "["); open >= 0 {
+			field = part[:open]
+			rest := part[open:]
+			for rest != "" {
+				if rest[0] != '[' {
+					return nil, fmt.Errorf("invalid path segment %q", part)
+				}
+				end := strings.Index(rest, "]")
+				if end < 0 {
+					return nil, fmt.Errorf("unclosed index in path segment %q", part)
+				}
+				indexes = append(indexes, rest[1:end])
+				rest = rest[end+1:]
+			}
+		}
+
+		if field != "" {
+			segments = append(segments, pathSegment{field: field})
+		}
+		for _, index := range indexes {
+			i, err := strconv.Atoi(index)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q in path segment %q", index, part)
+			}
+			segments = append(segments, pathSegment{index: i, isIndex: true})
+		}
+	}
+
+	return segments, nil
+}
@@ -25,15 +25,19 @@ type WeatherResponse struct {
 	ID   int    `json:"id"`
 }
 
+const defaultOpenWeatherBaseURL = "https://api.openweathermap.org/data/2.5"
+
 type OpenWeatherClient struct {
 	*BaseClient
-	apiKey string
+	apiKey  string
+	baseURL string
 }
 
 func NewOpenWeatherClient(config config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) *OpenWeatherClient {
 	return &OpenWeatherClient{
-		BaseClient: getBaseClient(config, retryConfig, logger),
+		BaseClient: newBaseClient(config, retryConfig, logger),
 		apiKey:     config.OpenWeatherAPIKey,
+		baseURL:    baseURLOrDefault(config.BaseURL, defaultOpenWeatherBaseURL),
 	}
 }
 
@@ -43,9 +47,6 @@ func (c *OpenWeatherClient) Fetch(ctx context.Context, apiName string, operation
 		return nil, errors.ExternalAPIFailed(fmt.Errorf("OpenWeather API key not configured"))
 	}
 
-	// Hardcoded OpenWeather API configuration
-	baseURL := "https://api.openweathermap.org/data/2.5"
-
 	var endpoint string
 	switch operation {
 	case "weather":
@@ -54,7 +55,7 @@ func (c *OpenWeatherClient) Fetch(ctx context.Context, apiName string, operation
 		return nil, errors.ExternalAPIFailed(fmt.Errorf("unsupported operation '%s' for OpenWeather API", operation))
 	}
 
-	url := fmt.Sprintf("%s%s", baseURL, endpoint)
+	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
 
 	// Handle parameters
 	if len(params) > 0 {
@@ -122,10 +123,12 @@ func (c *OpenWeatherClient) FetchPaginated(ctx context.Context, apiName string,
 }
 
 func (c *OpenWeatherClient) doRequest(ctx context.Context, method, url string, result interface{}) error {
-	breaker := c.breakerManager.GetBreaker("openweather-api")
+	breaker := c.breaker("openweather-api")
+	limiter := c.limiter("openweather-api")
+	retrier := c.retrier.Named("openweather-api")
 
 	return breaker.Execute(func() error {
-		return c.retrier.Execute(ctx, func() error {
+		return retrier.Execute(ctx, func() error {
 			req, err := http.NewRequestWithContext(ctx, method, url, nil)
 			if err != nil {
 				return retry.NewNonRetryableError(err)
@@ -133,12 +136,9 @@ func (c *OpenWeatherClient) doRequest(ctx context.Context, method, url string, r
 
 			req.Header.Set("Accept", "application/json")
 
-			err = c.BaseClient.doRequest(req, result)
+			err = c.BaseClient.doRequest(limiter, req, result)
 			if err != nil {
-				if shouldNotRetry(err) {
-					return retry.NewNonRetryableError(err)
-				}
-				return retry.NewRetryableError(err)
+				return classifyError(err)
 			}
 
 			return nil
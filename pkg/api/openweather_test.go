@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/pkg/fakeupstream"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+func TestOpenWeatherClient_Fetch_BaseURL(t *testing.T) {
+	catalog := fakeupstream.DefaultCatalog()
+	catalog.OpenWeatherAPIKey = "secret"
+	server, _ := fakeupstream.NewServer(catalog)
+	defer server.Close()
+
+	client := NewOpenWeatherClient(
+		config.APIConfig{Timeout: 5 * time.Second, OpenWeatherAPIKey: "secret", BaseURL: server.URL + fakeupstream.OpenWeatherPath + "/"},
+		config.RetryConfig{CircuitThreshold: 5, CircuitTimeout: time.Minute},
+		logger.NewLogger(logger.LevelError, "test"),
+	)
+
+	items, err := client.Fetch(context.Background(), "openweather", "weather", map[string]interface{}{"city": "Bandung"})
+
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 1650357, items[0].ID)
+	assert.Equal(t, "Bandung", items[0].Title)
+}
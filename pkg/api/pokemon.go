@@ -11,6 +11,7 @@ import (
 	"github.com/zainokta/item-sync/internal/errors"
 	"github.com/zainokta/item-sync/internal/item/entity"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
 	"github.com/zainokta/item-sync/pkg/retry"
 )
 
@@ -26,19 +27,25 @@ type PokemonItem struct {
 	URL  string `json:"url"`
 }
 
+const defaultPokemonBaseURL = "https://pokeapi.co/api/v2"
+
 type PokemonClient struct {
 	*BaseClient
+	config  config.APIConfig
+	baseURL string
+	details *detailCache
 }
 
 func NewPokemonClient(config config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) *PokemonClient {
 	return &PokemonClient{
-		BaseClient: getBaseClient(config, retryConfig, logger),
+		BaseClient: newBaseClient(config, retryConfig, logger),
+		config:     config,
+		baseURL:    baseURLOrDefault(config.BaseURL, defaultPokemonBaseURL),
+		details:    newDetailCache(config.PokemonEnrichCacheSize),
 	}
 }
 
 func (c *PokemonClient) Fetch(ctx context.Context, apiName string, operation string, params map[string]interface{}) ([]entity.ExternalItem, error) {
-	baseURL := "https://pokeapi.co/api/v2"
-
 	var endpoint string
 	switch operation {
 	case "list":
@@ -49,7 +56,7 @@ func (c *PokemonClient) Fetch(ctx context.Context, apiName string, operation str
 		return nil, errors.ExternalAPIFailed(fmt.Errorf("unsupported operation '%s' for Pokemon API", operation))
 	}
 
-	url := fmt.Sprintf("%s%s", baseURL, endpoint)
+	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
 
 	// Handle parameters
 	if len(params) > 0 {
@@ -117,8 +124,6 @@ func (c *PokemonClient) transformPokemonResponse(response PokemonResponse) []ent
 }
 
 func (c *PokemonClient) FetchPaginated(ctx context.Context, apiName string, operation string, params map[string]interface{}) (*PaginatedResponse, error) {
-	baseURL := "https://pokeapi.co/api/v2"
-
 	var endpoint string
 	switch operation {
 	case "list":
@@ -129,7 +134,7 @@ func (c *PokemonClient) FetchPaginated(ctx context.Context, apiName string, oper
 		return nil, errors.ExternalAPIFailed(fmt.Errorf("unsupported operation '%s' for Pokemon API", operation))
 	}
 
-	url := fmt.Sprintf("%s%s", baseURL, endpoint)
+	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
 
 	if len(params) > 0 {
 		if id, ok := params["id"].(int); ok {
@@ -152,7 +157,15 @@ func (c *PokemonClient) FetchPaginated(ctx context.Context, apiName string, oper
 	}
 
 	var response PokemonResponse
-	err := c.doRequest(ctx, http.MethodGet, url, &response)
+	var notModified bool
+	var validators *Validators
+	var err error
+	// Enriched pages also carry details, which can change while the listing does not
+	if c.config.PokemonEnrichDetails {
+		err = c.doRequest(ctx, http.MethodGet, url, &response)
+	} else {
+		notModified, validators, err = c.doConditionalRequest(ctx, url, &response)
+	}
 	if err != nil {
 		return nil, errors.ExternalAPIFailed(err)
 	}
@@ -160,7 +173,10 @@ func (c *PokemonClient) FetchPaginated(ctx context.Context, apiName string, oper
 	items := c.transformPokemonResponse(response)
 	pagination := NewPaginationMetadata(response.Count, response.Next, response.Previous)
 
-	return NewPaginatedResponse(items, pagination), nil
+	paginated := NewPaginatedResponse(items, pagination)
+	paginated.NotModified = notModified
+	paginated.Validators = validators
+	return paginated, nil
 }
 
 func (c *PokemonClient) extractPokemonID(url string) int {
@@ -175,10 +191,31 @@ func (c *PokemonClient) extractPokemonID(url string) int {
 }
 
 func (c *PokemonClient) doRequest(ctx context.Context, method, url string, result interface{}) error {
-	breaker := c.breakerManager.GetBreaker("pokemon-api")
+	_, err := c.execute(ctx, method, url, func(limiter *ratelimit.Limiter, req *http.Request) (bool, error) {
+		return false, c.BaseClient.doRequest(limiter, req, result)
+	})
+	return err
+}
+
+// doConditionalRequest is doRequest for listing pages, reporting whether the page was not modified along with the
+// validators of the last attempt
+func (c *PokemonClient) doConditionalRequest(ctx context.Context, url string, result interface{}) (notModified bool, validators *Validators, err error) {
+	notModified, err = c.execute(ctx, http.MethodGet, url, func(limiter *ratelimit.Limiter, req *http.Request) (bool, error) {
+		notModified, attempt, err := c.BaseClient.doConditionalRequest(limiter, req, result)
+		validators = attempt
+		return notModified, err
+	})
+	return notModified, validators, err
+}
+
+// execute runs send for every attempt of a request through the pokemon-api breaker and retrier
+func (c *PokemonClient) execute(ctx context.Context, method, url string, send func(*ratelimit.Limiter, *http.Request) (bool, error)) (notModified bool, err error) {
+	breaker := c.breaker("pokemon-api")
+	limiter := c.limiter("pokemon-api")
+	retrier := c.retrier.Named("pokemon-api")
 
-	return breaker.Execute(func() error {
-		return c.retrier.Execute(ctx, func() error {
+	err = breaker.Execute(func() error {
+		return retrier.Execute(ctx, func() error {
 			req, err := http.NewRequestWithContext(ctx, method, url, nil)
 			if err != nil {
 				return retry.NewNonRetryableError(err)
@@ -186,15 +223,13 @@ func (c *PokemonClient) doRequest(ctx context.Context, method, url string, resul
 
 			req.Header.Set("Accept", "application/json")
 
-			err = c.BaseClient.doRequest(req, result)
+			notModified, err = send(limiter, req)
 			if err != nil {
-				if shouldNotRetry(err) {
-					return retry.NewNonRetryableError(err)
-				}
-				return retry.NewRetryableError(err)
+				return classifyError(err)
 			}
 
 			return nil
 		})
 	})
+	return notModified, err
 }
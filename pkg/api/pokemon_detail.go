@@ -0,0 +1,292 @@
+package api
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+)
+
+type namedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// PokemonDetail is the part of a /pokemon/{id} response kept on enriched items
+type PokemonDetail struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Height         int    `json:"height"`
+	Weight         int    `json:"weight"`
+	BaseExperience int    `json:"base_experience"`
+	Types          []struct {
+		Slot int           `json:"slot"`
+		Type namedResource `json:"type"`
+	} `json:"types"`
+	Stats []struct {
+		BaseStat int           `json:"base_stat"`
+		Effort   int           `json:"effort"`
+		Stat     namedResource `json:"stat"`
+	} `json:"stats"`
+	Abilities []struct {
+		Ability  namedResource `json:"ability"`
+		IsHidden bool          `json:"is_hidden"`
+		Slot     int           `json:"slot"`
+	} `json:"abilities"`
+	Sprites struct {
+		FrontDefault string `json:"front_default"`
+		FrontShiny   string `json:"front_shiny"`
+		BackDefault  string `json:"back_default"`
+		BackShiny    string `json:"back_shiny"`
+		Other        struct {
+			OfficialArtwork struct {
+				FrontDefault string `json:"front_default"`
+			} `json:"official-artwork"`
+		} `json:"other"`
+	} `json:"sprites"`
+}
+
+// Hash identifies the detail content, fields PokeAPI returns but enrichment drops do not affect it
+func (d PokemonDetail) Hash() string {
+	data, _ := json.Marshal(d)
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// ExtendInfo returns the detail fields stored on an enriched item
+func (d PokemonDetail) ExtendInfo() map[string]interface{} {
+	types := make([]string, 0, len(d.Types))
+	for _, t := range d.Types {
+		types = append(types, t.Type.Name)
+	}
+
+	stats := make(map[string]interface{}, len(d.Stats))
+	for _, s := range d.Stats {
+		stats[s.Stat.Name] = s.BaseStat
+	}
+
+	abilities := make([]map[string]interface{}, 0, len(d.Abilities))
+	for _, a := range d.Abilities {
+		abilities = append(abilities, map[string]interface{}{
+			"name":      a.Ability.Name,
+			"is_hidden": a.IsHidden,
+		})
+	}
+
+	return map[string]interface{}{
+		"types":           types,
+		"stats":           stats,
+		"height":          d.Height,
+		"weight":          d.Weight,
+		"base_experience": d.BaseExperience,
+		"abilities":       abilities,
+		"sprites": map[string]interface{}{
+			"front_default":    d.Sprites.FrontDefault,
+			"front_shiny":      d.Sprites.FrontShiny,
+			"back_default":     d.Sprites.BackDefault,
+			"back_shiny":       d.Sprites.BackShiny,
+			"official_artwork": d.Sprites.Other.OfficialArtwork.FrontDefault,
+		},
+	}
+}
+
+// Description summarises the detail, e.g. "Grass/poison pokemon, 0.7 m, 6.9 kg, abilities: overgrow, chlorophyll"
+func (d PokemonDetail) Description() string {
+	types := make([]string, 0, len(d.Types))
+	for _, t := range d.Types {
+		types = append(types, t.Type.Name)
+	}
+	abilities := make([]string, 0, len(d.Abilities))
+	for _, a := range d.Abilities {
+		abilities = append(abilities, a.Ability.Name)
+	}
+
+	description := "Pokemon"
+	if len(types) > 0 {
+		typeNames := strings.Join(types, "/")
+		description = strings.ToUpper(typeNames[:1]) + typeNames[1:] + " pokemon"
+	}
+	// PokeAPI reports height in decimetres and weight in hectograms
+	description += fmt.Sprintf(", %.1f m, %.1f kg", float64(d.Height)/10, float64(d.Weight)/10)
+	if len(abilities) > 0 {
+		description += ", abilities: " + strings.Join(abilities, ", ")
+	}
+	return description
+}
+
+// cachedDetail is the last enrichment applied to a pokemon
+type cachedDetail struct {
+	url         string
+	hash        string
+	extendInfo  map[string]interface{}
+	description string
+	fetchedAt   time.Time
+}
+
+// detailCache holds the last enrichment of at most size pokemon, the least recently used one is evicted first
+type detailCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is the most recently used
+	entries map[int]*list.Element
+}
+
+type detailEntry struct {
+	id     int
+	detail cachedDetail
+}
+
+func newDetailCache(size int) *detailCache {
+	return &detailCache{
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: make(map[int]*list.Element),
+	}
+}
+
+func (d *detailCache) get(id int) (cachedDetail, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	element, ok := d.entries[id]
+	if !ok {
+		return cachedDetail{}, false
+	}
+	d.order.MoveToFront(element)
+	return element.Value.(*detailEntry).detail, true
+}
+
+func (d *detailCache) set(id int, cached cachedDetail) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if element, ok := d.entries[id]; ok {
+		element.Value.(*detailEntry).detail = cached
+		d.order.MoveToFront(element)
+		return
+	}
+
+	d.entries[id] = d.order.PushFront(&detailEntry{id: id, detail: cached})
+	if d.order.Len() > d.size {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.entries, oldest.Value.(*detailEntry).id)
+	}
+}
+
+// FetchDetail follows a pokemon URL from the list endpoint to its /pokemon/{id} detail
+func (c *PokemonClient) FetchDetail(ctx context.Context, url string) (PokemonDetail, error) {
+	var detail PokemonDetail
+	if err := c.doRequest(ctx, http.MethodGet, url, &detail); err != nil {
+		return PokemonDetail{}, errors.ExternalAPIFailed(err)
+	}
+	return detail, nil
+}
+
+// EnrichItems adds the /pokemon/{id} details to listed items when API_POKEMON_ENRICH_DETAILS is set.
+// Details are fetched by at most API_POKEMON_ENRICH_CONCURRENCY goroutines through the pokemon-api rate
+// limiter, and not refetched within API_POKEMON_ENRICH_REFRESH. A detail whose hash did not change keeps the
+// enrichment applied before. Items whose detail cannot be fetched keep their last enrichment, or are marked
+// Incomplete when there is none; only a done context fails the page.
+func (c *PokemonClient) EnrichItems(ctx context.Context, items []entity.ExternalItem) ([]entity.ExternalItem, error) {
+	if !c.config.PokemonEnrichDetails || len(items) == 0 {
+		return items, nil
+	}
+
+	enriched := make([]entity.ExternalItem, len(items))
+	copy(enriched, items)
+
+	workers := min(max(c.config.PokemonEnrichConcurrency, 1), len(items))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				c.enrichItem(ctx, &enriched[i])
+			}
+		}()
+	}
+
+feed:
+	for i := range enriched {
+		select {
+		case <-ctx.Done():
+			break feed
+		case queue <- i:
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return enriched, nil
+}
+
+func (c *PokemonClient) enrichItem(ctx context.Context, item *entity.ExternalItem) {
+	url, _ := item.ExtendInfo["url"].(string)
+	if url == "" {
+		return
+	}
+
+	cached, ok := c.details.get(item.ID)
+
+	if ok && cached.url == url && time.Since(cached.fetchedAt) < c.config.PokemonEnrichRefresh {
+		applyDetail(item, cached)
+		return
+	}
+
+	detail, err := c.FetchDetail(ctx, url)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.logger.Warn("Failed to fetch pokemon detail", "id", item.ID, "url", url, "error", err)
+		}
+		if ok {
+			applyDetail(item, cached)
+		} else {
+			item.Incomplete = true
+		}
+		return
+	}
+
+	hash := detail.Hash()
+	if ok && cached.url == url && cached.hash == hash {
+		c.logger.Debug("Pokemon detail unchanged", "id", item.ID)
+	} else {
+		cached = cachedDetail{
+			url:         url,
+			hash:        hash,
+			extendInfo:  detail.ExtendInfo(),
+			description: detail.Description(),
+		}
+	}
+	cached.fetchedAt = time.Now()
+
+	c.details.set(item.ID, cached)
+
+	applyDetail(item, cached)
+}
+
+// applyDetail merges the cached enrichment into a copy of the item extend info, cached maps are shared between runs
+func applyDetail(item *entity.ExternalItem, cached cachedDetail) {
+	extendInfo := make(map[string]interface{}, len(item.ExtendInfo)+len(cached.extendInfo)+1)
+	for k, v := range item.ExtendInfo {
+		extendInfo[k] = v
+	}
+	for k, v := range cached.extendInfo {
+		extendInfo[k] = v
+	}
+	extendInfo["detail_hash"] = cached.hash
+
+	item.ExtendInfo = extendInfo
+	item.Description = cached.description
+}
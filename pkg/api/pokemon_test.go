@@ -1,10 +1,18 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/pkg/logger"
 )
 
 func TestPokemonClient_extractPokemonID(t *testing.T) {
@@ -78,3 +86,118 @@ func TestPokemonClient_transformPokemonResponse(t *testing.T) {
 	assert.Equal(t, 3, items[2].ID, "Third item should have ID 3")
 	assert.Equal(t, "venusaur", items[2].Title, "Third item should have title 'venusaur'")
 }
+
+func newTestPokemonClient(t *testing.T, apiConfig config.APIConfig) *PokemonClient {
+	t.Helper()
+
+	apiConfig.Timeout = 5 * time.Second
+	return NewPokemonClient(
+		apiConfig,
+		config.RetryConfig{MaxRetries: 0, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1, CircuitThreshold: 100, CircuitTimeout: time.Second},
+		logger.NewLogger(logger.LevelError, "test"),
+	)
+}
+
+func TestPokemonClient_EnrichItems(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/pokemon/1/":
+			w.Write([]byte(`{
+				"id": 1, "name": "bulbasaur", "height": 7, "weight": 69, "base_experience": 64,
+				"types": [{"slot": 1, "type": {"name": "grass"}}, {"slot": 2, "type": {"name": "poison"}}],
+				"stats": [{"base_stat": 45, "effort": 0, "stat": {"name": "hp"}}],
+				"abilities": [{"ability": {"name": "overgrow"}, "is_hidden": false, "slot": 1}],
+				"sprites": {"front_default": "https://img/1.png", "other": {"official-artwork": {"front_default": "https://img/1-art.png"}}},
+				"moves": [{"move": {"name": "razor-wind"}}]
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestPokemonClient(t, config.APIConfig{
+		PokemonEnrichDetails:     true,
+		PokemonEnrichConcurrency: 2,
+		PokemonEnrichRefresh:     time.Hour,
+	})
+
+	items := client.transformPokemonResponse(PokemonResponse{Results: []PokemonItem{
+		{Name: "bulbasaur", URL: server.URL + "/pokemon/1/"},
+		{Name: "missingno", URL: server.URL + "/pokemon/0/"},
+	}})
+
+	enriched, err := client.EnrichItems(context.Background(), items)
+	require.NoError(t, err)
+	require.Len(t, enriched, 2)
+
+	bulbasaur := enriched[0]
+	assert.Equal(t, "Grass/poison pokemon, 0.7 m, 6.9 kg, abilities: overgrow", bulbasaur.Description)
+	assert.Equal(t, []string{"grass", "poison"}, bulbasaur.ExtendInfo["types"])
+	assert.Equal(t, map[string]interface{}{"hp": 45}, bulbasaur.ExtendInfo["stats"])
+	assert.Equal(t, 7, bulbasaur.ExtendInfo["height"])
+	assert.Equal(t, 69, bulbasaur.ExtendInfo["weight"])
+	assert.Equal(t, "https://img/1-art.png", bulbasaur.ExtendInfo["sprites"].(map[string]interface{})["official_artwork"])
+	assert.NotEmpty(t, bulbasaur.ExtendInfo["detail_hash"])
+	assert.Equal(t, server.URL+"/pokemon/1/", bulbasaur.ExtendInfo["url"], "List fields should be kept")
+	assert.Nil(t, items[0].ExtendInfo["types"], "Input items should not be modified")
+
+	assert.Empty(t, enriched[1].Description, "Item without detail should stay as listed")
+	assert.Nil(t, enriched[1].ExtendInfo["types"])
+	assert.True(t, enriched[1].Incomplete, "Item without detail should not be stored over an enriched one")
+	assert.False(t, bulbasaur.Incomplete)
+
+	requests.Store(0)
+	again, err := client.EnrichItems(context.Background(), items[:1])
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), requests.Load(), "Fresh details should not be refetched")
+	assert.Equal(t, bulbasaur.ExtendInfo, again[0].ExtendInfo)
+
+	// Another client, e.g. pointed at another upstream, keeps its own details
+	other := newTestPokemonClient(t, config.APIConfig{
+		PokemonEnrichDetails:     true,
+		PokemonEnrichConcurrency: 2,
+		PokemonEnrichRefresh:     time.Hour,
+	})
+	again, err = other.EnrichItems(context.Background(), items[:1])
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load(), "Details should not leak between clients")
+	assert.Equal(t, bulbasaur.ExtendInfo, again[0].ExtendInfo)
+}
+
+func TestDetailCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newDetailCache(2)
+	cache.set(1, cachedDetail{hash: "one"})
+	cache.set(2, cachedDetail{hash: "two"})
+
+	_, ok := cache.get(1)
+	require.True(t, ok)
+	cache.set(3, cachedDetail{hash: "three"})
+
+	_, ok = cache.get(2)
+	assert.False(t, ok, "The least recently used detail should be evicted")
+	one, ok := cache.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", one.hash)
+	_, ok = cache.get(3)
+	assert.True(t, ok)
+
+	cache.set(3, cachedDetail{hash: "updated"})
+	three, _ := cache.get(3)
+	assert.Equal(t, "updated", three.hash)
+	assert.Len(t, cache.entries, 2)
+}
+
+func TestPokemonClient_EnrichItems_Disabled(t *testing.T) {
+	client := newTestPokemonClient(t, config.APIConfig{})
+
+	items := client.transformPokemonResponse(PokemonResponse{Results: []PokemonItem{
+		{Name: "bulbasaur", URL: "http://127.0.0.1:0/pokemon/1/"},
+	}})
+
+	enriched, err := client.EnrichItems(context.Background(), items)
+	require.NoError(t, err)
+	assert.Equal(t, items, enriched)
+}
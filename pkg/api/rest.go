@@ -0,0 +1,359 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/internal/errors"
+	"github.com/zainokta/item-sync/internal/item/entity"
+	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/ratelimit"
+	"github.com/zainokta/item-sync/pkg/retry"
+)
+
+// PageTokenParam carries the pagination token from PaginationMetadata.Next into the next FetchPaginated call
+const PageTokenParam = "page_token"
+
+// RESTClient is an ExternalAPIClient driven entirely by a RESTProviderConfig
+type RESTClient struct {
+	*BaseClient
+	config    RESTProviderConfig
+	idPattern *regexp.Regexp
+}
+
+func NewRESTClient(providerConfig RESTProviderConfig, config config.APIConfig, retryConfig config.RetryConfig, logger logger.Logger) (*RESTClient, error) {
+	// API_<NAME>_BASE_URL wins over the file so that a deployment can point the provider elsewhere
+	providerConfig.BaseURL = baseURLOrDefault(config.BaseURL, providerConfig.BaseURL)
+
+	client := &RESTClient{
+		BaseClient: newBaseClient(config, retryConfig, logger),
+		config:     providerConfig,
+	}
+
+	if providerConfig.Mapping.IDPattern != "" {
+		pattern, err := regexp.Compile(providerConfig.Mapping.IDPattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid mapping.id_pattern: %w", providerConfig.Name, err)
+		}
+		client.idPattern = pattern
+	}
+
+	return client, nil
+}
+
+func (c *RESTClient) Fetch(ctx context.Context, apiName string, operation string, params map[string]interface{}) ([]entity.ExternalItem, error) {
+	response, err := c.FetchPaginated(ctx, apiName, operation, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+func (c *RESTClient) FetchByID(ctx context.Context, apiName string, id int) (entity.ExternalItem, error) {
+	endpoint, ok := c.config.Endpoints["get"]
+	if !ok {
+		return entity.ExternalItem{}, errors.ExternalAPIFailed(fmt.Errorf("FetchByID not supported for %s API", c.config.Name))
+	}
+
+	endpoint = strings.ReplaceAll(endpoint, "{id}", strconv.Itoa(id))
+
+	var body interface{}
+	if err := c.doRequest(ctx, http.MethodGet, c.resolveURL(endpoint), &body); err != nil {
+		return entity.ExternalItem{}, errors.ExternalAPIFailed(err)
+	}
+
+	item, ok := c.mapItem(body)
+	if !ok {
+		return entity.ExternalItem{}, errors.ExternalAPIFailed(fmt.Errorf("%s item %d could not be mapped", c.config.Name, id))
+	}
+
+	return item, nil
+}
+
+func (c *RESTClient) FetchPaginated(ctx context.Context, apiName string, operation string, params map[string]interface{}) (*PaginatedResponse, error) {
+	endpoint, ok := c.config.Endpoints[operation]
+	if !ok {
+		return nil, errors.ExternalAPIFailed(fmt.Errorf("unsupported operation '%s' for %s API", operation, c.config.Name))
+	}
+
+	pagination := c.config.Pagination
+	token, _ := params[PageTokenParam].(string)
+
+	var requestURL string
+	var offset, limit, page int
+
+	if pagination.Style == PaginationNextURL && token != "" {
+		requestURL = c.resolveURL(token)
+	} else {
+		query := url.Values{}
+		for key, value := range params {
+			if key == PageTokenParam {
+				continue
+			}
+			if formatted, ok := formatQueryValue(value); ok {
+				query.Set(key, formatted)
+			}
+		}
+
+		switch pagination.Style {
+		case PaginationOffset:
+			limit = intParam(params, pagination.LimitParam, pagination.PageSize)
+			offset = intParam(params, pagination.OffsetParam, 0)
+			if token != "" {
+				offset, _ = strconv.Atoi(token)
+			}
+			if limit > 0 {
+				query.Set(pagination.LimitParam, strconv.Itoa(limit))
+			}
+			query.Set(pagination.OffsetParam, strconv.Itoa(offset))
+		case PaginationPage:
+			page = intParam(params, pagination.PageParam, pagination.StartPage)
+			if token != "" {
+				page, _ = strconv.Atoi(token)
+			}
+			limit = intParam(params, pagination.LimitParam, pagination.PageSize)
+			if limit > 0 {
+				query.Set(pagination.LimitParam, strconv.Itoa(limit))
+			}
+			query.Set(pagination.PageParam, strconv.Itoa(page))
+		case PaginationCursor:
+			limit = intParam(params, pagination.LimitParam, pagination.PageSize)
+			if limit > 0 {
+				query.Set(pagination.LimitParam, strconv.Itoa(limit))
+			}
+			if token != "" {
+				query.Set(pagination.CursorParam, token)
+			}
+		}
+
+		requestURL = c.resolveURL(endpoint)
+		if len(query) > 0 {
+			requestURL = fmt.Sprintf("%s?%s", requestURL, query.Encode())
+		}
+	}
+
+	var body interface{}
+	notModified, validators, err := c.doConditionalRequest(ctx, requestURL, &body)
+	if err != nil {
+		return nil, errors.ExternalAPIFailed(err)
+	}
+
+	rawItems := c.extractItems(body)
+	items := make([]entity.ExternalItem, 0, len(rawItems))
+	for _, raw := range rawItems {
+		item, ok := c.mapItem(raw)
+		if !ok {
+			c.logger.Warn("Skipping item that could not be mapped", "api_source", c.config.Name)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	total := 0
+	if pagination.TotalPath != "" {
+		if value, ok := lookupPath(body, pagination.TotalPath); ok {
+			total, _ = toInt(value)
+		}
+	}
+
+	var next string
+	switch pagination.Style {
+	case PaginationOffset:
+		pageSize := limit
+		if pageSize <= 0 {
+			pageSize = len(rawItems)
+		}
+		nextOffset := offset + pageSize
+		if len(rawItems) > 0 && (limit <= 0 || len(rawItems) >= limit) && (total <= 0 || nextOffset < total) {
+			next = strconv.Itoa(nextOffset)
+		}
+	case PaginationPage:
+		if len(rawItems) > 0 && (limit <= 0 || len(rawItems) >= limit) {
+			next = strconv.Itoa(page + 1)
+		}
+	case PaginationCursor:
+		next = stringAt(body, pagination.NextCursorPath)
+	case PaginationNextURL:
+		next = stringAt(body, pagination.NextURLPath)
+	}
+
+	response := NewPaginatedResponse(items, NewPaginationMetadata(total, next, ""))
+	response.NotModified = notModified
+	response.Validators = validators
+	return response, nil
+}
+
+func (c *RESTClient) extractItems(body interface{}) []interface{} {
+	data := body
+	if c.config.ItemsPath != "" {
+		value, ok := lookupPath(body, c.config.ItemsPath)
+		if !ok {
+			return nil
+		}
+		data = value
+	}
+
+	switch value := data.(type) {
+	case []interface{}:
+		return value
+	case map[string]interface{}:
+		return []interface{}{value}
+	default:
+		return nil
+	}
+}
+
+func (c *RESTClient) mapItem(raw interface{}) (entity.ExternalItem, bool) {
+	idValue, ok := lookupPath(raw, c.config.Mapping.ID)
+	if !ok {
+		return entity.ExternalItem{}, false
+	}
+
+	if c.idPattern != nil {
+		matches := c.idPattern.FindStringSubmatch(fmt.Sprint(idValue))
+		if len(matches) < 2 {
+			return entity.ExternalItem{}, false
+		}
+		idValue = matches[1]
+	}
+
+	id, ok := toInt(idValue)
+	if !ok || id <= 0 {
+		return entity.ExternalItem{}, false
+	}
+
+	title := stringAt(raw, c.config.Mapping.Title)
+	if title == "" {
+		return entity.ExternalItem{}, false
+	}
+
+	extendInfo := map[string]interface{}{
+		"api_source": c.config.Name,
+	}
+	if len(c.config.Mapping.ExtendInfo) == 0 {
+		extendInfo["raw_data"] = raw
+	}
+	for key, path := range c.config.Mapping.ExtendInfo {
+		if value, ok := lookupPath(raw, path); ok {
+			extendInfo[key] = value
+		}
+	}
+
+	return entity.ExternalItem{
+		ID:         id,
+		Title:      title,
+		ExtendInfo: extendInfo,
+	}, true
+}
+
+// resolveURL joins a relative endpoint or next URL with the base URL, absolute URLs are returned as is
+func (c *RESTClient) resolveURL(ref string) string {
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		return ref
+	}
+	return strings.TrimRight(c.config.BaseURL, "/") + "/" + strings.TrimLeft(ref, "/")
+}
+
+func (c *RESTClient) doRequest(ctx context.Context, method, url string, result interface{}) error {
+	_, err := c.execute(ctx, method, url, func(limiter *ratelimit.Limiter, req *http.Request) (bool, error) {
+		return false, c.BaseClient.doRequest(limiter, req, result)
+	})
+	return err
+}
+
+// doConditionalRequest is doRequest for listing pages, reporting whether the page was not modified along with the
+// validators of the last attempt
+func (c *RESTClient) doConditionalRequest(ctx context.Context, url string, result interface{}) (notModified bool, validators *Validators, err error) {
+	notModified, err = c.execute(ctx, http.MethodGet, url, func(limiter *ratelimit.Limiter, req *http.Request) (bool, error) {
+		notModified, attempt, err := c.BaseClient.doConditionalRequest(limiter, req, result)
+		validators = attempt
+		return notModified, err
+	})
+	return notModified, validators, err
+}
+
+// execute runs send for every attempt of an authenticated request through the provider breaker and retrier
+func (c *RESTClient) execute(ctx context.Context, method, url string, send func(*ratelimit.Limiter, *http.Request) (bool, error)) (notModified bool, err error) {
+	breaker := c.breaker(c.config.Name + "-api")
+	limiter := c.limiter(c.config.Name + "-api")
+	retrier := c.retrier.Named(c.config.Name + "-api")
+
+	err = breaker.Execute(func() error {
+		return retrier.Execute(ctx, func() error {
+			req, err := http.NewRequestWithContext(ctx, method, url, nil)
+			if err != nil {
+				return retry.NewNonRetryableError(err)
+			}
+
+			req.Header.Set("Accept", "application/json")
+			if c.config.Auth.Header != "" {
+				req.Header.Set(c.config.Auth.Header, os.ExpandEnv(c.config.Auth.Value))
+			}
+
+			notModified, err = send(limiter, req)
+			if err != nil {
+				return classifyError(err)
+			}
+
+			return nil
+		})
+	})
+	return notModified, err
+}
+
+func stringAt(data interface{}, path string) string {
+	value, ok := lookupPath(data, path)
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+func intParam(params map[string]interface{}, key string, fallback int) int {
+	if value, ok := params[key]; ok {
+		if i, ok := toInt(value); ok {
+			return i
+		}
+	}
+	return fallback
+}
+
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case string:
+		i, err := strconv.Atoi(strings.TrimSpace(v))
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func formatQueryValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool, int, int64:
+		return fmt.Sprint(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Pagination styles supported by the REST provider
+const (
+	PaginationNone    = "none"
+	PaginationOffset  = "offset"
+	PaginationPage    = "page"
+	PaginationCursor  = "cursor"
+	PaginationNextURL = "next_url"
+)
+
+// RESTProviderConfig declares a paginated JSON API that is synced without a dedicated client type
+type RESTProviderConfig struct {
+	Name             string                 `yaml:"name" json:"name"`
+	BaseURL          string                 `yaml:"base_url" json:"base_url"`
+	Endpoints        map[string]string      `yaml:"endpoints" json:"endpoints"`
+	DefaultOperation string                 `yaml:"default_operation" json:"default_operation"`
+	DefaultParams    map[string]interface{} `yaml:"default_params" json:"default_params"`
+	Auth             RESTAuthConfig         `yaml:"auth" json:"auth"`
+	Pagination       RESTPaginationConfig   `yaml:"pagination" json:"pagination"`
+	ItemsPath        string                 `yaml:"items_path" json:"items_path"`
+	Mapping          RESTMappingConfig      `yaml:"mapping" json:"mapping"`
+}
+
+// RESTAuthConfig sets a static auth header, the value is expanded with environment variables
+type RESTAuthConfig struct {
+	Header string `yaml:"header" json:"header"`
+	Value  string `yaml:"value" json:"value"`
+}
+
+type RESTPaginationConfig struct {
+	Style          string `yaml:"style" json:"style"`
+	PageSize       int    `yaml:"page_size" json:"page_size"`
+	MaxPages       int    `yaml:"max_pages" json:"max_pages"`
+	LimitParam     string `yaml:"limit_param" json:"limit_param"`
+	OffsetParam    string `yaml:"offset_param" json:"offset_param"`
+	PageParam      string `yaml:"page_param" json:"page_param"`
+	StartPage      int    `yaml:"start_page" json:"start_page"`
+	CursorParam    string `yaml:"cursor_param" json:"cursor_param"`
+	NextCursorPath string `yaml:"next_cursor_path" json:"next_cursor_path"`
+	NextURLPath    string `yaml:"next_url_path" json:"next_url_path"`
+	TotalPath      string `yaml:"total_path" json:"total_path"`
+}
+
+// RESTMappingConfig maps fields of a single upstream item to entity.ExternalItem
+type RESTMappingConfig struct {
+	ID         string            `yaml:"id" json:"id"`
+	IDPattern  string            `yaml:"id_pattern" json:"id_pattern"`
+	Title      string            `yaml:"title" json:"title"`
+	ExtendInfo map[string]string `yaml:"extend_info" json:"extend_info"`
+}
+
+// LoadRESTProviderConfigs reads provider definitions from a YAML or JSON file
+func LoadRESTProviderConfigs(path string) ([]RESTProviderConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read providers file: %w", err)
+	}
+
+	var file struct {
+		Providers []RESTProviderConfig `yaml:"providers" json:"providers"`
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse providers file: %w", err)
+	}
+
+	for i := range file.Providers {
+		file.Providers[i].applyDefaults()
+		if err := file.Providers[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid provider #%d: %w", i+1, err)
+		}
+	}
+
+	return file.Providers, nil
+}
+
+func (c *RESTProviderConfig) applyDefaults() {
+	if c.DefaultOperation == "" {
+		c.DefaultOperation = "list"
+	}
+
+	p := &c.Pagination
+	if p.Style == "" {
+		p.Style = PaginationNone
+	}
+	if p.LimitParam == "" {
+		p.LimitParam = "limit"
+	}
+	if p.OffsetParam == "" {
+		p.OffsetParam = "offset"
+	}
+	if p.PageParam == "" {
+		p.PageParam = "page"
+	}
+	if p.StartPage == 0 {
+		p.StartPage = 1
+	}
+	if p.CursorParam == "" {
+		p.CursorParam = "cursor"
+	}
+	if p.NextURLPath == "" {
+		p.NextURLPath = "$.next"
+	}
+}
+
+func (c RESTProviderConfig) Validate() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if c.BaseURL == "" {
+		return fmt.Errorf("%s: base_url is required", c.Name)
+	}
+	if _, ok := c.Endpoints[c.DefaultOperation]; !ok {
+		return fmt.Errorf("%s: no endpoint for default operation %q", c.Name, c.DefaultOperation)
+	}
+	if c.Mapping.ID == "" || c.Mapping.Title == "" {
+		return fmt.Errorf("%s: mapping.id and mapping.title are required", c.Name)
+	}
+	// A limit param turns a sync into a single page fetch, scheduled syncs would never see the whole listing
+	if _, ok := c.DefaultParams["limit"]; ok {
+		return fmt.Errorf("%s: default_params must not set limit, use pagination.page_size", c.Name)
+	}
+
+	switch c.Pagination.Style {
+	case PaginationNone, PaginationOffset, PaginationPage, PaginationNextURL:
+	case PaginationCursor:
+		if c.Pagination.NextCursorPath == "" {
+			return fmt.Errorf("%s: pagination.next_cursor_path is required for cursor pagination", c.Name)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported pagination style %q", c.Name, c.Pagination.Style)
+	}
+
+	return nil
+}
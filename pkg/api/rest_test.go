@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+func newTestRESTClient(t *testing.T, providerConfig RESTProviderConfig) *RESTClient {
+	t.Helper()
+
+	providerConfig.applyDefaults()
+	require.NoError(t, providerConfig.Validate())
+
+	client, err := NewRESTClient(
+		providerConfig,
+		config.APIConfig{Timeout: 5 * time.Second},
+		config.RetryConfig{MaxRetries: 0, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1, CircuitThreshold: 100, CircuitTimeout: time.Second},
+		logger.NewLogger(logger.LevelError, "test"),
+	)
+	require.NoError(t, err)
+	return client
+}
+
+func TestRESTClient_FetchPaginated_Offset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/products", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+
+		var body string
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			body = `{"total": 3, "data": [{"id": 1, "name": "one", "price": 10}, {"id": 2, "name": "two", "price": 20}]}`
+		case "2":
+			body = `{"total": 3, "data": [{"id": 3, "name": "three", "price": 30}]}`
+		default:
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_REST_API_KEY", "secret")
+
+	client := newTestRESTClient(t, RESTProviderConfig{
+		Name:      "products",
+		BaseURL:   server.URL,
+		Endpoints: map[string]string{"list": "/products"},
+		Auth:      RESTAuthConfig{Header: "X-API-Key", Value: "${TEST_REST_API_KEY}"},
+		Pagination: RESTPaginationConfig{
+			Style:     PaginationOffset,
+			PageSize:  2,
+			TotalPath: "$.total",
+		},
+		ItemsPath: "$.data",
+		Mapping: RESTMappingConfig{
+			ID:         "id",
+			Title:      "name",
+			ExtendInfo: map[string]string{"price": "price"},
+		},
+	})
+
+	first, err := client.FetchPaginated(context.Background(), "products", "list", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Len(t, first.Items, 2)
+	assert.Equal(t, 1, first.Items[0].ID)
+	assert.Equal(t, "one", first.Items[0].Title)
+	assert.Equal(t, float64(10), first.Items[0].ExtendInfo["price"])
+	assert.Equal(t, "products", first.Items[0].ExtendInfo["api_source"])
+	assert.True(t, first.Pagination.HasNext)
+	assert.Equal(t, "2", first.Pagination.Next)
+	assert.Equal(t, 3, first.Pagination.Count)
+
+	second, err := client.FetchPaginated(context.Background(), "products", "list", map[string]interface{}{PageTokenParam: first.Pagination.Next})
+	require.NoError(t, err)
+	assert.Len(t, second.Items, 1)
+	assert.False(t, second.Pagination.HasNext)
+}
+
+func TestRESTClient_FetchPaginated_NextURL(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon/1/"},
+			},
+		}
+		if r.URL.Query().Get("offset") == "" {
+			response["next"] = server.URL + "/pokemon?offset=1"
+		} else {
+			response["results"] = []map[string]interface{}{
+				{"name": "ivysaur", "url": "https://pokeapi.co/api/v2/pokemon/2/"},
+			}
+			response["next"] = nil
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := newTestRESTClient(t, RESTProviderConfig{
+		Name:       "pokemon-rest",
+		BaseURL:    server.URL,
+		Endpoints:  map[string]string{"list": "/pokemon"},
+		Pagination: RESTPaginationConfig{Style: PaginationNextURL},
+		ItemsPath:  "results",
+		Mapping: RESTMappingConfig{
+			ID:        "url",
+			IDPattern: `/pokemon/(\d+)/?$`,
+			Title:     "name",
+		},
+	})
+
+	first, err := client.FetchPaginated(context.Background(), "pokemon-rest", "list", nil)
+	require.NoError(t, err)
+	require.Len(t, first.Items, 1)
+	assert.Equal(t, 1, first.Items[0].ID)
+	assert.NotNil(t, first.Items[0].ExtendInfo["raw_data"], "Should store raw data when no extend_info mapping is set")
+	assert.True(t, first.Pagination.HasNext)
+
+	second, err := client.FetchPaginated(context.Background(), "pokemon-rest", "list", map[string]interface{}{PageTokenParam: first.Pagination.Next})
+	require.NoError(t, err)
+	require.Len(t, second.Items, 1)
+	assert.Equal(t, 2, second.Items[0].ID)
+	assert.False(t, second.Pagination.HasNext)
+}
+
+func TestRESTClient_FetchPaginated_Cursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("after") {
+		case "":
+			w.Write([]byte(`{"items": [{"id": "7", "title": "seven"}, {"title": "missing id"}], "meta": {"next": "abc"}}`))
+		case "abc":
+			w.Write([]byte(`{"items": [{"id": "8", "title": "eight"}], "meta": {"next": null}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestRESTClient(t, RESTProviderConfig{
+		Name:      "cursor",
+		BaseURL:   server.URL,
+		Endpoints: map[string]string{"list": "/items"},
+		Pagination: RESTPaginationConfig{
+			Style:          PaginationCursor,
+			CursorParam:    "after",
+			NextCursorPath: "meta.next",
+		},
+		ItemsPath: "items",
+		Mapping:   RESTMappingConfig{ID: "id", Title: "title"},
+	})
+
+	first, err := client.FetchPaginated(context.Background(), "cursor", "list", nil)
+	require.NoError(t, err)
+	assert.Len(t, first.Items, 1, "Items without an ID should be skipped")
+	assert.Equal(t, "abc", first.Pagination.Next)
+
+	second, err := client.FetchPaginated(context.Background(), "cursor", "list", map[string]interface{}{PageTokenParam: "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, 8, second.Items[0].ID)
+	assert.False(t, second.Pagination.HasNext)
+}
+
+func TestRESTClient_FetchByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/products/42", r.URL.Path)
+		w.Write([]byte(`{"id": 42, "name": "answer"}`))
+	}))
+	defer server.Close()
+
+	client := newTestRESTClient(t, RESTProviderConfig{
+		Name:      "products",
+		BaseURL:   server.URL,
+		Endpoints: map[string]string{"list": "/products", "get": "/products/{id}"},
+		Mapping:   RESTMappingConfig{ID: "id", Title: "name"},
+	})
+
+	item, err := client.FetchByID(context.Background(), "products", 42)
+	require.NoError(t, err)
+	assert.Equal(t, 42, item.ID)
+	assert.Equal(t, "answer", item.Title)
+}
+
+func TestLoadRESTProviderConfigs(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "providers.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte(`
+providers:
+  - name: products
+    base_url: https://example.com/api
+    endpoints:
+      list: /products
+    pagination:
+      style: page
+      page_size: 50
+    items_path: data
+    mapping:
+      id: id
+      title: name
+`), 0o600))
+
+	configs, err := LoadRESTProviderConfigs(valid)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "list", configs[0].DefaultOperation)
+	assert.Equal(t, "page", configs[0].Pagination.PageParam)
+	assert.Equal(t, 1, configs[0].Pagination.StartPage)
+
+	invalid := filepath.Join(dir, "providers.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"providers": [{"name": "broken", "base_url": "https://example.com", "endpoints": {"list": "/x"}}]}`), 0o600))
+
+	_, err = LoadRESTProviderConfigs(invalid)
+	assert.Error(t, err, "Should reject a provider without a mapping")
+
+	limited := filepath.Join(dir, "limited.json")
+	require.NoError(t, os.WriteFile(limited, []byte(`{"providers": [{"name": "limited", "base_url": "https://example.com", "endpoints": {"list": "/x"}, "default_params": {"limit": 10}, "mapping": {"id": "id", "title": "name"}}]}`), 0o600))
+
+	_, err = LoadRESTProviderConfigs(limited)
+	assert.ErrorContains(t, err, "default_params must not set limit", "Should reject a limit that would make every scheduled sync fetch a single page")
+}
+
+func TestLookupPath(t *testing.T) {
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"a": {"b": [{"c": 1}, {"c": 2}]}}`), &data))
+
+	tests := []struct {
+		name  string
+		path  string
+		want  interface{}
+		found bool
+	}{
+		{name: "root marker", path: "$.a.b[1].c", want: float64(2), found: true},
+		{name: "without root marker", path: "a.b[0].c", want: float64(1), found: true},
+		{name: "index out of range", path: "a.b[5].c", found: false},
+		{name: "missing field", path: "a.x", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := lookupPath(data, tt.path)
+			assert.Equal(t, tt.found, ok)
+			if tt.found {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -15,6 +15,14 @@ type PaginationMetadata struct {
 type PaginatedResponse struct {
 	Items      []entity.ExternalItem `json:"items"`
 	Pagination *PaginationMetadata   `json:"pagination,omitempty"`
+
+	// NotModified is set when the upstream answered a conditional request with 304, Items and Pagination then come
+	// from the response cached for the page
+	NotModified bool `json:"not_modified,omitempty"`
+
+	// Validators of a modified page, to be committed once its items are stored. Nil when the page was not modified
+	// or sent no validators.
+	Validators *Validators `json:"-"`
 }
 
 // NewPaginatedResponse creates a new paginated response
@@ -1,12 +1,15 @@
 package circuit
 
 import (
+	"context"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/zainokta/item-sync/config"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/metrics"
 )
 
 type State int
@@ -30,114 +33,205 @@ func (s State) String() string {
 	}
 }
 
-var ErrCircuitOpen = errors.New("circuit breaker is open")
+var (
+	ErrCircuitOpen     = errors.New("circuit breaker is open")
+	ErrBreakerNotFound = errors.New("circuit breaker not found")
+)
+
+// Snapshot is the state of a breaker as shown to operators
+type Snapshot struct {
+	Name          string     `json:"name" example:"pokemon-api"`
+	State         string     `json:"state" example:"OPEN" description:"CLOSED, OPEN or HALF_OPEN"`
+	Forced        bool       `json:"forced" description:"Set while the state was forced by an operator and failures or timeouts do not change it"`
+	FailureCount  int        `json:"failure_count" example:"5"`
+	Threshold     int        `json:"threshold" example:"5"`
+	WindowCalls   int        `json:"window_calls,omitempty" example:"20" description:"Calls in the failure rate window, when a failure rate is set"`
+	FailureRate   float64    `json:"failure_rate,omitempty" example:"0.25" description:"Share of failed calls in the failure rate window"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	NextRetryAt   *time.Time `json:"next_retry_at,omitempty" description:"When an open breaker lets the next call through"`
+}
+
+// Classifier reports whether a failed call counts against the breaker, calls it rejects count as neither a failure
+// nor a success
+type Classifier func(err error) bool
+
+// DefaultClassifier counts every error except cancellations, a caller giving up says nothing about the upstream
+func DefaultClassifier(err error) bool {
+	return err != nil && !errors.Is(err, context.Canceled)
+}
 
 type CircuitBreaker struct {
-	name      string
-	threshold int
-	timeout   time.Duration
-	logger    logger.Logger
+	name           string
+	threshold      int
+	timeout        time.Duration
+	halfOpenProbes int
+	failureRate    float64 // 0 when only consecutive failures open the breaker
+	minCalls       int
+	classify       Classifier
+	logger         logger.Logger
 
-	mu            sync.RWMutex
-	state         State
-	failureCount  int
-	successCount  int
-	lastFailTime  time.Time
-	nextRetryTime time.Time
+	mu             sync.RWMutex
+	state          State
+	failureCount   int
+	successCount   int
+	lastFailTime   time.Time
+	nextRetryTime  time.Time
+	forced         bool // set by ForceOpen and ForceClose until Reset
+	provisional    bool // forced open by an operator before any client used it, see BreakerManager.ForceOpen
+	window         *window
+	halfOpenSeq    uint64 // identifies the current half-open period so late probes of an earlier one are ignored
+	probesInFlight int
+	probeSuccesses int
 }
 
 func NewCircuitBreaker(name string, config config.RetryConfig, logger logger.Logger) *CircuitBreaker {
 	return &CircuitBreaker{
-		name:      name,
-		threshold: config.CircuitThreshold,
-		timeout:   config.CircuitTimeout,
-		logger:    logger,
-		state:     StateClosed,
+		name:           name,
+		threshold:      config.CircuitThreshold,
+		timeout:        config.CircuitTimeout,
+		halfOpenProbes: max(config.CircuitHalfOpenProbes, 1),
+		failureRate:    config.CircuitFailureRate,
+		minCalls:       config.CircuitMinCalls,
+		classify:       DefaultClassifier,
+		logger:         logger,
+		state:          StateClosed,
+		window:         newWindow(config.CircuitWindow, config.CircuitWindowSize),
 	}
 }
 
+// Execute runs the operation unless the breaker is open, or half-open with all probe calls already in flight
 func (cb *CircuitBreaker) Execute(operation func() error) error {
-	if !cb.canExecute() {
+	allowed, probe := cb.acquire()
+	if !allowed {
 		cb.logger.Warn("Circuit breaker is open, rejecting request", "name", cb.name)
 		return ErrCircuitOpen
 	}
 
 	err := operation()
-	cb.recordResult(err)
+	cb.recordResult(err, probe)
 	return err
 }
 
-func (cb *CircuitBreaker) canExecute() bool {
-	cb.mu.RLock()
-	defer cb.mu.RUnlock()
+// acquire reports whether a call may run and, for calls let through while half-open, the half-open period they probe
+func (cb *CircuitBreaker) acquire() (allowed bool, probe uint64) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
 
 	switch cb.state {
 	case StateClosed:
-		return true
+		return true, 0
 	case StateOpen:
-		return time.Now().After(cb.nextRetryTime)
-	case StateHalfOpen:
-		return true
-	default:
-		return false
+		if cb.forced || time.Now().Before(cb.nextRetryTime) {
+			return false, 0
+		}
+		cb.setState(StateHalfOpen)
+		cb.halfOpenSeq++
+		cb.probesInFlight = 0
+		cb.probeSuccesses = 0
+		cb.logger.Info("Circuit breaker moved to half-open state", "name", cb.name, "probes", cb.halfOpenProbes)
+	}
+
+	if cb.probesInFlight+cb.probeSuccesses >= cb.halfOpenProbes {
+		return false, 0
 	}
+	cb.probesInFlight++
+	return true, cb.halfOpenSeq
 }
 
-func (cb *CircuitBreaker) recordResult(err error) {
+func (cb *CircuitBreaker) recordResult(err error, probe uint64) {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
-	if err != nil {
-		cb.recordFailure()
-	} else {
-		cb.recordSuccess()
+	isProbe := probe != 0 && cb.state == StateHalfOpen && probe == cb.halfOpenSeq
+	if isProbe {
+		cb.probesInFlight--
+	}
+
+	switch {
+	case err == nil:
+		cb.recordSuccess(isProbe)
+	case cb.classify(err):
+		cb.recordFailure(isProbe)
+	default:
+		cb.logger.Debug("Circuit breaker ignoring error", "name", cb.name, "error", err)
 	}
 }
 
-func (cb *CircuitBreaker) recordFailure() {
+func (cb *CircuitBreaker) recordFailure(isProbe bool) {
 	cb.failureCount++
 	cb.lastFailTime = time.Now()
 
-	switch cb.state {
-	case StateClosed:
+	if cb.forced {
+		return
+	}
+
+	switch {
+	case cb.state == StateClosed:
+		calls, failures := cb.record(true)
 		if cb.failureCount >= cb.threshold {
-			cb.setState(StateOpen)
-			cb.nextRetryTime = time.Now().Add(cb.timeout)
+			cb.open()
 			cb.logger.Warn("Circuit breaker opened due to failures",
 				"name", cb.name,
 				"failures", cb.failureCount,
 				"threshold", cb.threshold)
+		} else if cb.rateExceeded(calls, failures) {
+			cb.open()
+			cb.logger.Warn("Circuit breaker opened due to failure rate",
+				"name", cb.name,
+				"calls", calls,
+				"failures", failures,
+				"failure_rate", cb.failureRate)
 		}
-	case StateHalfOpen:
-		cb.setState(StateOpen)
-		cb.nextRetryTime = time.Now().Add(cb.timeout)
+	case isProbe:
+		cb.open()
 		cb.logger.Warn("Circuit breaker reopened after failed recovery attempt", "name", cb.name)
 	}
 }
 
-func (cb *CircuitBreaker) recordSuccess() {
+func (cb *CircuitBreaker) recordSuccess(isProbe bool) {
 	cb.successCount++
 
-	switch cb.state {
-	case StateClosed:
-		cb.reset()
-	case StateHalfOpen:
-		cb.setState(StateClosed)
-		cb.reset()
-		cb.logger.Info("Circuit breaker closed after successful recovery", "name", cb.name)
-	case StateOpen:
-		if time.Now().After(cb.nextRetryTime) {
-			cb.setState(StateHalfOpen)
-			cb.logger.Info("Circuit breaker moved to half-open state", "name", cb.name)
+	if cb.forced {
+		return
+	}
+
+	switch {
+	case cb.state == StateClosed:
+		cb.failureCount = 0
+		cb.record(false)
+	case isProbe:
+		cb.probeSuccesses++
+		if cb.probeSuccesses >= cb.halfOpenProbes {
+			cb.setState(StateClosed)
+			cb.reset()
+			cb.logger.Info("Circuit breaker closed after successful recovery", "name", cb.name)
 		}
 	}
 }
 
+// record adds a call made while closed to the failure rate window and returns the calls and failures it holds
+func (cb *CircuitBreaker) record(failed bool) (calls, failures int) {
+	if cb.failureRate <= 0 {
+		return 0, 0
+	}
+	return cb.window.record(time.Now(), failed)
+}
+
+func (cb *CircuitBreaker) rateExceeded(calls, failures int) bool {
+	return cb.failureRate > 0 && calls > 0 && calls >= cb.minCalls && float64(failures)/float64(calls) >= cb.failureRate
+}
+
+func (cb *CircuitBreaker) open() {
+	cb.setState(StateOpen)
+	cb.nextRetryTime = time.Now().Add(cb.timeout)
+}
+
 func (cb *CircuitBreaker) setState(state State) {
 	oldState := cb.state
 	cb.state = state
 
 	if oldState != state {
+		metrics.BreakerTransitions.WithLabelValues(cb.name, oldState.String(), state.String()).Inc()
 		cb.logger.Debug("Circuit breaker state changed",
 			"name", cb.name,
 			"from", oldState.String(),
@@ -148,26 +242,139 @@ func (cb *CircuitBreaker) setState(state State) {
 func (cb *CircuitBreaker) reset() {
 	cb.failureCount = 0
 	cb.successCount = 0
+	cb.probesInFlight = 0
+	cb.probeSuccesses = 0
+	cb.window.clear()
+}
+
+// Snapshot returns the current state of the breaker
+func (cb *CircuitBreaker) Snapshot() Snapshot {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	snapshot := Snapshot{
+		Name:         cb.name,
+		State:        cb.state.String(),
+		Forced:       cb.forced,
+		FailureCount: cb.failureCount,
+		Threshold:    cb.threshold,
+	}
+	if cb.failureRate > 0 {
+		calls, failures := cb.window.counts(time.Now())
+		snapshot.WindowCalls = calls
+		if calls > 0 {
+			snapshot.FailureRate = float64(failures) / float64(calls)
+		}
+	}
+	if !cb.lastFailTime.IsZero() {
+		lastFailTime := cb.lastFailTime
+		snapshot.LastFailureAt = &lastFailTime
+	}
+	if cb.state == StateOpen && !cb.forced {
+		nextRetryTime := cb.nextRetryTime
+		snapshot.NextRetryAt = &nextRetryTime
+	}
+	return snapshot
+}
+
+// ForceOpen rejects every call until ForceClose or Reset, e.g. to shed load from an upstream during an incident
+func (cb *CircuitBreaker) ForceOpen() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.setState(StateOpen)
+	cb.forced = true
+	cb.logger.Warn("Circuit breaker forced open", "name", cb.name)
+}
+
+// ForceClose lets every call through until ForceOpen or Reset, failures are still counted but do not open the breaker
+func (cb *CircuitBreaker) ForceClose() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.setState(StateClosed)
+	cb.forced = true
+	cb.logger.Warn("Circuit breaker forced closed", "name", cb.name)
+}
+
+// configure replaces the settings of a breaker created by BreakerManager.ForceOpen with those of its first client,
+// the state and counters are kept
+func (cb *CircuitBreaker) configure(config config.RetryConfig, classify Classifier, logger logger.Logger) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.threshold = config.CircuitThreshold
+	cb.timeout = config.CircuitTimeout
+	cb.halfOpenProbes = max(config.CircuitHalfOpenProbes, 1)
+	cb.failureRate = config.CircuitFailureRate
+	cb.minCalls = config.CircuitMinCalls
+	cb.classify = classify
+	cb.logger = logger
+	cb.window = newWindow(config.CircuitWindow, config.CircuitWindowSize)
+	cb.provisional = false
+}
+
+// Reset returns the breaker to automatic operation in the closed state with no recorded failures
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.setState(StateClosed)
+	cb.forced = false
+	cb.reset()
+	cb.lastFailTime = time.Time{}
+	cb.nextRetryTime = time.Time{}
+	cb.logger.Info("Circuit breaker reset", "name", cb.name)
 }
 
 type BreakerManager struct {
 	breakers map[string]*CircuitBreaker
 	mu       sync.RWMutex
 	config   config.RetryConfig
+	classify Classifier
 	logger   logger.Logger
 }
 
+// NewBreakerManager creates a manager whose breakers default to config, logger is used for the breakers created by
+// GetBreaker and ForceOpen
 func NewBreakerManager(config config.RetryConfig, logger logger.Logger) *BreakerManager {
 	return &BreakerManager{
 		breakers: make(map[string]*CircuitBreaker),
 		config:   config,
+		classify: DefaultClassifier,
 		logger:   logger,
 	}
 }
 
+// WithClassifier sets the classifier of the breakers created from now on
+func (bm *BreakerManager) WithClassifier(classify Classifier) *BreakerManager {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	bm.classify = classify
+	return bm
+}
+
+// WithLogger sets the logger of the breakers created by GetBreaker and ForceOpen from now on
+func (bm *BreakerManager) WithLogger(logger logger.Logger) *BreakerManager {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	bm.logger = logger
+	return bm
+}
+
+// GetBreaker returns the named breaker, creating it with the manager settings on first use
 func (bm *BreakerManager) GetBreaker(name string) *CircuitBreaker {
+	return bm.GetBreakerWithConfig(name, bm.config, bm.logger)
+}
+
+// GetBreakerWithConfig returns the named breaker, creating it on first use with the given settings and the
+// RETRY_<NAME>_* overrides of the breaker name, see config.RetryConfig.ForBreaker. A breaker forced open before its
+// first use takes these settings too and stays forced open.
+func (bm *BreakerManager) GetBreakerWithConfig(name string, config config.RetryConfig, logger logger.Logger) *CircuitBreaker {
 	bm.mu.RLock()
-	if breaker, exists := bm.breakers[name]; exists {
+	if breaker, exists := bm.breakers[name]; exists && !breaker.provisional {
 		bm.mu.RUnlock()
 		return breaker
 	}
@@ -176,13 +383,94 @@ func (bm *BreakerManager) GetBreaker(name string) *CircuitBreaker {
 	bm.mu.Lock()
 	defer bm.mu.Unlock()
 
-	if breaker, exists := bm.breakers[name]; exists {
+	breaker, exists := bm.breakers[name]
+	if exists && !breaker.provisional {
+		return breaker
+	}
+
+	breakerConfig, err := config.ForBreaker(name)
+	if err != nil {
+		logger.Warn("Ignoring invalid circuit breaker overrides", "name", name, "error", err)
+		breakerConfig = config
+	}
+
+	if exists {
+		breaker.configure(breakerConfig, bm.classify, logger)
+		logger.Info("Configured circuit breaker forced open before its first use",
+			"name", name,
+			"threshold", breakerConfig.CircuitThreshold,
+			"timeout", breakerConfig.CircuitTimeout)
 		return breaker
 	}
 
-	breaker := NewCircuitBreaker(name, bm.config, bm.logger)
+	breaker = NewCircuitBreaker(name, breakerConfig, logger)
+	breaker.classify = bm.classify
 	bm.breakers[name] = breaker
-	bm.logger.Info("Created new circuit breaker", "name", name)
+	logger.Info("Created new circuit breaker",
+		"name", name,
+		"threshold", breakerConfig.CircuitThreshold,
+		"timeout", breakerConfig.CircuitTimeout,
+		"half_open_probes", breaker.halfOpenProbes,
+		"failure_rate", breakerConfig.CircuitFailureRate)
 
 	return breaker
 }
+
+// Snapshots returns the state of every breaker ordered by name
+func (bm *BreakerManager) Snapshots() []Snapshot {
+	bm.mu.RLock()
+	breakers := make([]*CircuitBreaker, 0, len(bm.breakers))
+	for _, breaker := range bm.breakers {
+		breakers = append(breakers, breaker)
+	}
+	bm.mu.RUnlock()
+
+	snapshots := make([]Snapshot, 0, len(breakers))
+	for _, breaker := range breakers {
+		snapshots = append(snapshots, breaker.Snapshot())
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Name < snapshots[j].Name })
+	return snapshots
+}
+
+// ForceOpen forces the named breaker open, see CircuitBreaker.ForceOpen. A breaker no client used yet is created with
+// the manager settings, so an upstream can be cut off before the first call, and takes the settings of the first
+// client that uses it.
+func (bm *BreakerManager) ForceOpen(name string) (Snapshot, error) {
+	bm.mu.Lock()
+	breaker, exists := bm.breakers[name]
+	if !exists {
+		breaker = NewCircuitBreaker(name, bm.config, bm.logger)
+		breaker.classify = bm.classify
+		breaker.provisional = true
+		bm.breakers[name] = breaker
+	}
+	bm.mu.Unlock()
+
+	breaker.ForceOpen()
+	return breaker.Snapshot(), nil
+}
+
+// ForceClose forces the named breaker closed, see CircuitBreaker.ForceClose
+func (bm *BreakerManager) ForceClose(name string) (Snapshot, error) {
+	return bm.apply(name, (*CircuitBreaker).ForceClose)
+}
+
+// Reset resets the named breaker, see CircuitBreaker.Reset
+func (bm *BreakerManager) Reset(name string) (Snapshot, error) {
+	return bm.apply(name, (*CircuitBreaker).Reset)
+}
+
+// apply runs an action on an existing breaker
+func (bm *BreakerManager) apply(name string, action func(*CircuitBreaker)) (Snapshot, error) {
+	bm.mu.RLock()
+	breaker, exists := bm.breakers[name]
+	bm.mu.RUnlock()
+
+	if !exists {
+		return Snapshot{}, ErrBreakerNotFound
+	}
+
+	action(breaker)
+	return breaker.Snapshot(), nil
+}
@@ -0,0 +1,245 @@
+package circuit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+var (
+	errUpstream = errors.New("upstream failed")
+	errNotFound = errors.New("not found")
+)
+
+func newTestManager() *BreakerManager {
+	return NewBreakerManager(config.RetryConfig{CircuitThreshold: 2, CircuitTimeout: time.Minute}, logger.NewLogger(logger.LevelError, "test"))
+}
+
+func TestCircuitBreaker_Snapshot(t *testing.T) {
+	manager := newTestManager()
+	breaker := manager.GetBreaker("pokemon-api")
+
+	snapshot := breaker.Snapshot()
+	assert.Equal(t, "CLOSED", snapshot.State)
+	assert.Nil(t, snapshot.LastFailureAt)
+	assert.Nil(t, snapshot.NextRetryAt)
+
+	breaker.Execute(func() error { return errUpstream })
+	breaker.Execute(func() error { return errUpstream })
+
+	snapshot = breaker.Snapshot()
+	assert.Equal(t, "OPEN", snapshot.State)
+	assert.Equal(t, 2, snapshot.FailureCount)
+	assert.Equal(t, 2, snapshot.Threshold)
+	require.NotNil(t, snapshot.LastFailureAt)
+	require.NotNil(t, snapshot.NextRetryAt)
+	assert.WithinDuration(t, snapshot.LastFailureAt.Add(time.Minute), *snapshot.NextRetryAt, time.Second)
+
+	manager.GetBreaker("openweather-api")
+	snapshots := manager.Snapshots()
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, "openweather-api", snapshots[0].Name, "Snapshots should be ordered by name")
+}
+
+func TestBreakerManager_ForceOpen(t *testing.T) {
+	manager := newTestManager()
+	breaker := manager.GetBreaker("pokemon-api")
+
+	snapshot, err := manager.ForceOpen("pokemon-api")
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", snapshot.State)
+	assert.True(t, snapshot.Forced)
+	assert.Nil(t, snapshot.NextRetryAt, "A forced open breaker has no automatic retry")
+
+	called := false
+	err = breaker.Execute(func() error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.False(t, called)
+}
+
+func TestBreakerManager_ForceCloseAndReset(t *testing.T) {
+	manager := newTestManager()
+	breaker := manager.GetBreaker("pokemon-api")
+
+	_, err := manager.ForceClose("pokemon-api")
+	require.NoError(t, err)
+	for range 3 {
+		breaker.Execute(func() error { return errUpstream })
+	}
+
+	snapshot := breaker.Snapshot()
+	assert.Equal(t, "CLOSED", snapshot.State, "Failures should not open a forced closed breaker")
+	assert.Equal(t, 3, snapshot.FailureCount, "Failures should still be counted")
+
+	snapshot, err = manager.Reset("pokemon-api")
+	require.NoError(t, err)
+	assert.Equal(t, Snapshot{Name: "pokemon-api", State: "CLOSED", Threshold: 2}, snapshot)
+
+	breaker.Execute(func() error { return errUpstream })
+	breaker.Execute(func() error { return errUpstream })
+	assert.Equal(t, "OPEN", breaker.Snapshot().State, "A reset breaker should open on failures again")
+}
+
+func TestBreakerManager_UnknownBreaker(t *testing.T) {
+	manager := newTestManager()
+
+	_, err := manager.Reset("unknown-api")
+	assert.ErrorIs(t, err, ErrBreakerNotFound)
+}
+
+func TestBreakerManager_ForceOpenBeforeFirstUse(t *testing.T) {
+	manager := newTestManager()
+
+	snapshot, err := manager.ForceOpen("pokemon-api")
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", snapshot.State)
+	assert.True(t, snapshot.Forced)
+
+	clientConfig := config.RetryConfig{CircuitThreshold: 7, CircuitTimeout: time.Minute}
+	breaker := manager.GetBreakerWithConfig("pokemon-api", clientConfig, logger.NewLogger(logger.LevelError, "test"))
+	assert.ErrorIs(t, breaker.Execute(func() error { return nil }), ErrCircuitOpen, "The breaker should stay forced open")
+
+	snapshot = breaker.Snapshot()
+	assert.True(t, snapshot.Forced)
+	assert.Equal(t, 7, snapshot.Threshold, "The first client should configure the breaker")
+	assert.Same(t, breaker, manager.GetBreakerWithConfig("pokemon-api", config.RetryConfig{CircuitThreshold: 3}, nil))
+	assert.Equal(t, 7, breaker.Snapshot().Threshold, "Only the first client should configure the breaker")
+
+	_, err = manager.Reset("pokemon-api")
+	require.NoError(t, err)
+	for range 6 {
+		breaker.Execute(func() error { return errUpstream })
+	}
+	assert.Equal(t, "CLOSED", breaker.Snapshot().State)
+	breaker.Execute(func() error { return errUpstream })
+	assert.Equal(t, "OPEN", breaker.Snapshot().State)
+}
+
+func TestCircuitBreaker_HalfOpenProbes(t *testing.T) {
+	breaker := NewCircuitBreaker("pokemon-api", config.RetryConfig{
+		CircuitThreshold:      1,
+		CircuitTimeout:        time.Millisecond,
+		CircuitHalfOpenProbes: 2,
+	}, logger.NewLogger(logger.LevelError, "test"))
+
+	breaker.Execute(func() error { return errUpstream })
+	time.Sleep(5 * time.Millisecond)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	done := make(chan error, 2)
+	for range 2 {
+		go func() {
+			done <- breaker.Execute(func() error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+	}
+	<-started
+	<-started
+
+	assert.Equal(t, "HALF_OPEN", breaker.Snapshot().State)
+	assert.ErrorIs(t, breaker.Execute(func() error { return nil }), ErrCircuitOpen, "Calls beyond the probes should be rejected")
+
+	close(release)
+	require.NoError(t, <-done)
+	require.NoError(t, <-done)
+	assert.Equal(t, "CLOSED", breaker.Snapshot().State, "The breaker should close once all probes succeeded")
+}
+
+func TestCircuitBreaker_HalfOpenProbeFailure(t *testing.T) {
+	breaker := NewCircuitBreaker("pokemon-api", config.RetryConfig{
+		CircuitThreshold: 1,
+		CircuitTimeout:   time.Millisecond,
+	}, logger.NewLogger(logger.LevelError, "test"))
+
+	breaker.Execute(func() error { return errUpstream })
+	time.Sleep(5 * time.Millisecond)
+
+	assert.ErrorIs(t, breaker.Execute(func() error { return errUpstream }), errUpstream)
+	assert.Equal(t, "OPEN", breaker.Snapshot().State, "A failed probe should reopen the breaker")
+	assert.ErrorIs(t, breaker.Execute(func() error { return nil }), ErrCircuitOpen)
+}
+
+func TestCircuitBreaker_FailureRate(t *testing.T) {
+	breaker := NewCircuitBreaker("pokemon-api", config.RetryConfig{
+		CircuitThreshold:   100,
+		CircuitTimeout:     time.Minute,
+		CircuitFailureRate: 0.5,
+		CircuitWindowSize:  4,
+		CircuitMinCalls:    4,
+	}, logger.NewLogger(logger.LevelError, "test"))
+
+	// Alternating results never reach the consecutive threshold
+	for _, err := range []error{nil, errUpstream, nil, nil, nil, errUpstream} {
+		breaker.Execute(func() error { return err })
+	}
+	snapshot := breaker.Snapshot()
+	assert.Equal(t, "CLOSED", snapshot.State, "One failure in the last four calls should stay below the rate")
+	assert.Equal(t, 4, snapshot.WindowCalls)
+	assert.Equal(t, 0.25, snapshot.FailureRate)
+
+	breaker.Execute(func() error { return nil })
+	breaker.Execute(func() error { return errUpstream })
+	assert.Equal(t, "OPEN", breaker.Snapshot().State, "Two failures in the last four calls should open the breaker")
+}
+
+func TestCircuitBreaker_FailureRateTimeWindow(t *testing.T) {
+	breaker := NewCircuitBreaker("pokemon-api", config.RetryConfig{
+		CircuitThreshold:   100,
+		CircuitTimeout:     time.Minute,
+		CircuitFailureRate: 0.5,
+		CircuitWindow:      20 * time.Millisecond,
+		CircuitMinCalls:    2,
+	}, logger.NewLogger(logger.LevelError, "test"))
+
+	breaker.Execute(func() error { return errUpstream })
+	time.Sleep(30 * time.Millisecond)
+	breaker.Execute(func() error { return nil })
+	breaker.Execute(func() error { return nil })
+
+	snapshot := breaker.Snapshot()
+	assert.Equal(t, 2, snapshot.WindowCalls, "Calls older than the window should be dropped")
+	assert.Zero(t, snapshot.FailureRate)
+
+	breaker.Execute(func() error { return errUpstream })
+	breaker.Execute(func() error { return errUpstream })
+	assert.Equal(t, "OPEN", breaker.Snapshot().State)
+}
+
+func TestCircuitBreaker_Classifier(t *testing.T) {
+	manager := newTestManager().WithClassifier(func(err error) bool {
+		return DefaultClassifier(err) && !errors.Is(err, errNotFound)
+	})
+	breaker := manager.GetBreaker("pokemon-api")
+
+	breaker.Execute(func() error { return context.Canceled })
+	breaker.Execute(func() error { return errNotFound })
+	breaker.Execute(func() error { return errNotFound })
+
+	snapshot := breaker.Snapshot()
+	assert.Equal(t, "CLOSED", snapshot.State)
+	assert.Zero(t, snapshot.FailureCount, "Errors the classifier rejects should not count")
+}
+
+func TestBreakerManager_PerBreakerOverrides(t *testing.T) {
+	t.Setenv("RETRY_POKEMON_API_CIRCUIT_THRESHOLD", "1")
+
+	manager := newTestManager()
+	pokemon := manager.GetBreaker("pokemon-api")
+	openWeather := manager.GetBreaker("openweather-api")
+
+	assert.Equal(t, 1, pokemon.Snapshot().Threshold)
+	assert.Equal(t, 2, openWeather.Snapshot().Threshold)
+}
@@ -0,0 +1,59 @@
+package circuit
+
+import "time"
+
+type outcome struct {
+	at     time.Time
+	failed bool
+}
+
+// window holds the outcomes of the last size calls, or of the calls made in the last duration when size is 0
+type window struct {
+	duration time.Duration
+	size     int
+	outcomes []outcome
+}
+
+func newWindow(duration time.Duration, size int) *window {
+	return &window{duration: duration, size: size}
+}
+
+// record adds an outcome and returns the calls and failures in the window
+func (w *window) record(now time.Time, failed bool) (calls, failures int) {
+	w.outcomes = append(w.outcomes, outcome{at: now, failed: failed})
+	if w.size > 0 && len(w.outcomes) > w.size {
+		w.outcomes = w.outcomes[len(w.outcomes)-w.size:]
+	} else if w.size <= 0 {
+		w.outcomes = w.outcomes[w.expired(now):]
+	}
+	return w.counts(now)
+}
+
+// counts returns the calls and failures in the window without dropping expired outcomes
+func (w *window) counts(now time.Time) (calls, failures int) {
+	for _, o := range w.outcomes[w.expired(now):] {
+		calls++
+		if o.failed {
+			failures++
+		}
+	}
+	return calls, failures
+}
+
+// expired returns how many of the oldest outcomes fell out of a time window
+func (w *window) expired(now time.Time) int {
+	if w.size > 0 {
+		return 0
+	}
+	cutoff := now.Add(-w.duration)
+	for i, o := range w.outcomes {
+		if o.at.After(cutoff) {
+			return i
+		}
+	}
+	return len(w.outcomes)
+}
+
+func (w *window) clear() {
+	w.outcomes = nil
+}
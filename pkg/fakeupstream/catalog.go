@@ -0,0 +1,99 @@
+package fakeupstream
+
+// Pokemon is a canned PokeAPI pokemon
+type Pokemon struct {
+	ID        int
+	Name      string
+	Types     []string
+	Height    int // decimetres
+	Weight    int // hectograms
+	Abilities []string
+	Stats     map[string]int
+}
+
+// Weather is the canned current weather of a city
+type Weather struct {
+	ID          int
+	City        string
+	Temp        float64
+	Humidity    int
+	Main        string
+	Description string
+}
+
+// Catalog is the data a fake upstream serves
+type Catalog struct {
+	Pokemon []Pokemon
+	Weather []Weather
+
+	// OpenWeatherAPIKey is the appid weather requests must carry, empty accepts any key
+	OpenWeatherAPIKey string
+}
+
+// DefaultCatalog returns the first 25 pokemon and the weather of the cities synced by default
+func DefaultCatalog() Catalog {
+	rows := []struct {
+		name      string
+		types     []string
+		height    int
+		weight    int
+		abilities []string
+	}{
+		{"bulbasaur", []string{"grass", "poison"}, 7, 69, []string{"overgrow", "chlorophyll"}},
+		{"ivysaur", []string{"grass", "poison"}, 10, 130, []string{"overgrow", "chlorophyll"}},
+		{"venusaur", []string{"grass", "poison"}, 20, 1000, []string{"overgrow", "chlorophyll"}},
+		{"charmander", []string{"fire"}, 6, 85, []string{"blaze", "solar-power"}},
+		{"charmeleon", []string{"fire"}, 11, 190, []string{"blaze", "solar-power"}},
+		{"charizard", []string{"fire", "flying"}, 17, 905, []string{"blaze", "solar-power"}},
+		{"squirtle", []string{"water"}, 5, 90, []string{"torrent", "rain-dish"}},
+		{"wartortle", []string{"water"}, 10, 225, []string{"torrent", "rain-dish"}},
+		{"blastoise", []string{"water"}, 16, 855, []string{"torrent", "rain-dish"}},
+		{"caterpie", []string{"bug"}, 3, 29, []string{"shield-dust", "run-away"}},
+		{"metapod", []string{"bug"}, 7, 99, []string{"shed-skin"}},
+		{"butterfree", []string{"bug", "flying"}, 11, 320, []string{"compound-eyes", "tinted-lens"}},
+		{"weedle", []string{"bug", "poison"}, 3, 32, []string{"shield-dust", "run-away"}},
+		{"kakuna", []string{"bug", "poison"}, 6, 100, []string{"shed-skin"}},
+		{"beedrill", []string{"bug", "poison"}, 10, 295, []string{"swarm", "sniper"}},
+		{"pidgey", []string{"normal", "flying"}, 3, 18, []string{"keen-eye", "tangled-feet", "big-pecks"}},
+		{"pidgeotto", []string{"normal", "flying"}, 11, 300, []string{"keen-eye", "tangled-feet", "big-pecks"}},
+		{"pidgeot", []string{"normal", "flying"}, 15, 395, []string{"keen-eye", "tangled-feet", "big-pecks"}},
+		{"rattata", []string{"normal"}, 3, 35, []string{"run-away", "guts", "hustle"}},
+		{"raticate", []string{"normal"}, 7, 185, []string{"run-away", "guts", "hustle"}},
+		{"spearow", []string{"normal", "flying"}, 3, 20, []string{"keen-eye", "sniper"}},
+		{"fearow", []string{"normal", "flying"}, 12, 380, []string{"keen-eye", "sniper"}},
+		{"ekans", []string{"poison"}, 20, 69, []string{"intimidate", "shed-skin", "unnerve"}},
+		{"arbok", []string{"poison"}, 35, 650, []string{"intimidate", "shed-skin", "unnerve"}},
+		{"pikachu", []string{"electric"}, 4, 60, []string{"static", "lightning-rod"}},
+	}
+
+	catalog := Catalog{
+		Weather: []Weather{
+			{ID: 1642911, City: "Jakarta", Temp: 31.2, Humidity: 70, Main: "Clouds", Description: "broken clouds"},
+			{ID: 1650357, City: "Bandung", Temp: 24.5, Humidity: 82, Main: "Rain", Description: "light rain"},
+			{ID: 1625822, City: "Surabaya", Temp: 32.8, Humidity: 63, Main: "Clear", Description: "clear sky"},
+		},
+	}
+
+	for i, row := range rows {
+		id := i + 1
+		// Stats are synthetic but stable, enough to tell pokemon apart
+		catalog.Pokemon = append(catalog.Pokemon, Pokemon{
+			ID:        id,
+			Name:      row.name,
+			Types:     row.types,
+			Height:    row.height,
+			Weight:    row.weight,
+			Abilities: row.abilities,
+			Stats: map[string]int{
+				"hp":              40 + id,
+				"attack":          45 + id*2,
+				"defense":         40 + id*2,
+				"special-attack":  50 + id,
+				"special-defense": 50 + id,
+				"speed":           45 + id*3,
+			},
+		})
+	}
+
+	return catalog
+}
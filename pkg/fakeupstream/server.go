@@ -0,0 +1,225 @@
+// Package fakeupstream serves canned PokeAPI and OpenWeather responses so that syncs can run without network
+// access. Point a source at it with API_POKEMON_BASE_URL=<server>/api/v2 and
+// API_OPENWEATHER_BASE_URL=<server>/data/2.5. Pokemon listing pages carry an ETag and honor If-None-Match.
+package fakeupstream
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Path prefixes of the APIs, mirroring the public base URLs
+const (
+	PokemonPath     = "/api/v2"
+	OpenWeatherPath = "/data/2.5"
+)
+
+const defaultPokemonPageSize = 20
+
+// Handler serves a Catalog, the catalog can be replaced while serving to simulate upstream changes
+type Handler struct {
+	mu      sync.RWMutex
+	catalog Catalog
+	mux     *http.ServeMux
+}
+
+func NewHandler(catalog Catalog) *Handler {
+	h := &Handler{catalog: catalog, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET "+PokemonPath+"/pokemon", h.listPokemon)
+	h.mux.HandleFunc("GET "+PokemonPath+"/pokemon/{id}", h.getPokemon)
+	h.mux.HandleFunc("GET "+PokemonPath+"/pokemon/{id}/", h.getPokemon)
+	h.mux.HandleFunc("GET "+OpenWeatherPath+"/weather", h.getWeather)
+	return h
+}
+
+// NewServer starts an httptest server for the catalog, callers must Close it
+func NewServer(catalog Catalog) (*httptest.Server, *Handler) {
+	handler := NewHandler(catalog)
+	return httptest.NewServer(handler), handler
+}
+
+// SetCatalog replaces the served data
+func (h *Handler) SetCatalog(catalog Catalog) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.catalog = catalog
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) snapshot() Catalog {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.catalog
+}
+
+func (h *Handler) listPokemon(w http.ResponseWriter, r *http.Request) {
+	pokemon := slices.Clone(h.snapshot().Pokemon)
+	sort.Slice(pokemon, func(i, j int) bool { return pokemon[i].ID < pokemon[j].ID })
+
+	offset := queryInt(r, "offset", 0)
+	limit := queryInt(r, "limit", defaultPokemonPageSize)
+	if offset < 0 || limit <= 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "invalid offset or limit"})
+		return
+	}
+
+	base := baseURL(r) + PokemonPath + "/pokemon"
+	results := make([]map[string]string, 0, limit)
+	for i := offset; i < len(pokemon) && i < offset+limit; i++ {
+		results = append(results, map[string]string{
+			"name": pokemon[i].Name,
+			"url":  fmt.Sprintf("%s/%d/", base, pokemon[i].ID),
+		})
+	}
+
+	var next, previous interface{}
+	if offset+limit < len(pokemon) {
+		next = fmt.Sprintf("%s?offset=%d&limit=%d", base, offset+limit, limit)
+	}
+	if offset > 0 {
+		previous = fmt.Sprintf("%s?offset=%d&limit=%d", base, max(offset-limit, 0), limit)
+	}
+
+	writeCacheableJSON(w, r, map[string]interface{}{
+		"count":    len(pokemon),
+		"next":     next,
+		"previous": previous,
+		"results":  results,
+	})
+}
+
+func (h *Handler) getPokemon(w http.ResponseWriter, r *http.Request) {
+	ref := r.PathValue("id")
+	for _, p := range h.snapshot().Pokemon {
+		if strconv.Itoa(p.ID) == ref || p.Name == ref {
+			writeJSON(w, http.StatusOK, pokemonDetail(p))
+			return
+		}
+	}
+	http.Error(w, "Not Found", http.StatusNotFound)
+}
+
+// pokemonDetail renders a pokemon in the /pokemon/{id} shape, including a field enrichment ignores
+func pokemonDetail(p Pokemon) map[string]interface{} {
+	types := make([]map[string]interface{}, 0, len(p.Types))
+	for i, name := range p.Types {
+		types = append(types, map[string]interface{}{"slot": i + 1, "type": map[string]string{"name": name}})
+	}
+
+	statNames := make([]string, 0, len(p.Stats))
+	for name := range p.Stats {
+		statNames = append(statNames, name)
+	}
+	sort.Strings(statNames)
+	stats := make([]map[string]interface{}, 0, len(statNames))
+	for _, name := range statNames {
+		stats = append(stats, map[string]interface{}{"base_stat": p.Stats[name], "effort": 0, "stat": map[string]string{"name": name}})
+	}
+
+	abilities := make([]map[string]interface{}, 0, len(p.Abilities))
+	for i, name := range p.Abilities {
+		abilities = append(abilities, map[string]interface{}{
+			"ability":   map[string]string{"name": name},
+			"is_hidden": len(p.Abilities) > 1 && i == len(p.Abilities)-1,
+			"slot":      i + 1,
+		})
+	}
+
+	sprite := fmt.Sprintf("https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/%d.png", p.ID)
+	return map[string]interface{}{
+		"id":              p.ID,
+		"name":            p.Name,
+		"height":          p.Height,
+		"weight":          p.Weight,
+		"base_experience": 50 + p.ID,
+		"types":           types,
+		"stats":           stats,
+		"abilities":       abilities,
+		"sprites": map[string]interface{}{
+			"front_default": sprite,
+			"other": map[string]interface{}{
+				"official-artwork": map[string]string{"front_default": sprite},
+			},
+		},
+		"moves": []interface{}{},
+	}
+}
+
+func (h *Handler) getWeather(w http.ResponseWriter, r *http.Request) {
+	catalog := h.snapshot()
+
+	if appID := r.URL.Query().Get("appid"); appID == "" || (catalog.OpenWeatherAPIKey != "" && appID != catalog.OpenWeatherAPIKey) {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"cod": 401, "message": "Invalid API key."})
+		return
+	}
+
+	city := r.URL.Query().Get("q")
+	for _, weather := range catalog.Weather {
+		if strings.EqualFold(weather.City, city) {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"id":   weather.ID,
+				"name": weather.City,
+				"main": map[string]interface{}{"temp": weather.Temp, "humidity": weather.Humidity},
+				"weather": []map[string]string{
+					{"main": weather.Main, "description": weather.Description},
+				},
+			})
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"cod": "404", "message": "city not found"})
+}
+
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func queryInt(r *http.Request, key string, fallback int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// writeCacheableJSON answers with an ETag of the body, and with 304 Not Modified when the client already has it
+func writeCacheableJSON(w http.ResponseWriter, r *http.Request, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,124 @@
+package fakeupstream
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getJSON(t *testing.T, url string, body interface{}) int {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if body != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(body))
+	}
+	return resp.StatusCode
+}
+
+func TestServer_ListPokemon(t *testing.T) {
+	server, _ := NewServer(DefaultCatalog())
+	defer server.Close()
+
+	var page struct {
+		Count    int     `json:"count"`
+		Next     *string `json:"next"`
+		Previous *string `json:"previous"`
+		Results  []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"results"`
+	}
+	status := getJSON(t, server.URL+PokemonPath+"/pokemon?offset=20&limit=10", &page)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 25, page.Count)
+	assert.Len(t, page.Results, 5)
+	assert.Equal(t, server.URL+PokemonPath+"/pokemon/21/", page.Results[0].URL)
+	assert.Nil(t, page.Next, "The last page should have no next link")
+	if assert.NotNil(t, page.Previous) {
+		assert.Equal(t, server.URL+PokemonPath+"/pokemon?offset=10&limit=10", *page.Previous)
+	}
+}
+
+func TestServer_GetPokemon(t *testing.T) {
+	server, handler := NewServer(DefaultCatalog())
+	defer server.Close()
+
+	var detail struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Types []struct {
+			Type struct {
+				Name string `json:"name"`
+			} `json:"type"`
+		} `json:"types"`
+	}
+	status := getJSON(t, server.URL+PokemonPath+"/pokemon/1/", &detail)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "bulbasaur", detail.Name)
+	if assert.NotEmpty(t, detail.Types) {
+		assert.Equal(t, "grass", detail.Types[0].Type.Name)
+	}
+
+	handler.SetCatalog(Catalog{})
+	assert.Equal(t, http.StatusNotFound, getJSON(t, server.URL+PokemonPath+"/pokemon/1", nil), "Replaced catalogs should be served")
+}
+
+func TestServer_GetWeather(t *testing.T) {
+	catalog := DefaultCatalog()
+	catalog.OpenWeatherAPIKey = "secret"
+	server, _ := NewServer(catalog)
+	defer server.Close()
+
+	var weather struct {
+		Name string `json:"name"`
+		Main struct {
+			Temp float64 `json:"temp"`
+		} `json:"main"`
+	}
+	status := getJSON(t, server.URL+OpenWeatherPath+"/weather?q=jakarta&appid=secret", &weather)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Jakarta", weather.Name)
+	assert.NotZero(t, weather.Main.Temp)
+
+	assert.Equal(t, http.StatusUnauthorized, getJSON(t, server.URL+OpenWeatherPath+"/weather?q=Jakarta&appid=wrong", nil))
+	assert.Equal(t, http.StatusNotFound, getJSON(t, server.URL+OpenWeatherPath+"/weather?q=Atlantis&appid=secret", nil))
+}
+
+func TestServer_ListPokemon_NotModified(t *testing.T) {
+	server, handler := NewServer(DefaultCatalog())
+	defer server.Close()
+
+	url := server.URL + PokemonPath + "/pokemon?offset=0&limit=20"
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	conditionalGet := func() int {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		req.Header.Set("If-None-Match", etag)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusNotModified, conditionalGet())
+
+	catalog := DefaultCatalog()
+	catalog.Pokemon[0].Name = "bulbasaur-renamed"
+	handler.SetCatalog(catalog)
+	assert.Equal(t, http.StatusOK, conditionalGet(), "A changed page should be served in full")
+}
@@ -0,0 +1,130 @@
+// Package health runs the dependency checks behind the readiness probe and reports the status and latency of each
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Overall statuses of a Report
+const (
+	StatusReady    = "ready"
+	StatusDegraded = "degraded" // an optional dependency failed
+	StatusNotReady = "not_ready"
+)
+
+// Statuses of a single check
+const (
+	CheckUp   = "up"
+	CheckDown = "down"
+)
+
+// CheckFunc probes one dependency, details are reported along with the result whether the check fails or not
+type CheckFunc func(ctx context.Context) (details map[string]interface{}, err error)
+
+// Check is a named dependency probe. The service is not ready while a required check fails, failing optional checks
+// only degrade it.
+type Check struct {
+	Name     string
+	Required bool
+	Run      CheckFunc
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Status    string                 `json:"status" example:"up"`
+	Required  bool                   `json:"required"`
+	LatencyMs float64                `json:"latency_ms" example:"1.25"`
+	Error     string                 `json:"error,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report is the outcome of all checks, keyed by check name
+type Report struct {
+	Status string            `json:"status" example:"ready"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Ready reports whether every required check passed
+func (r Report) Ready() bool {
+	return r.Status != StatusNotReady
+}
+
+type Checker struct {
+	checks  []Check
+	timeout time.Duration
+}
+
+// NewChecker creates a checker that gives every check at most timeout to answer
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+// Run runs all checks concurrently. A check that does not answer within the timeout is reported as down, even when
+// its function ignores the context.
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{Status: StatusReady, Checks: make(map[string]Result, len(c.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := c.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status == CheckUp {
+				return
+			}
+			if check.Required {
+				report.Status = StatusNotReady
+			} else if report.Status == StatusReady {
+				report.Status = StatusDegraded
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+type outcome struct {
+	details map[string]interface{}
+	err     error
+}
+
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		details, err := check.Run(ctx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		out.err = fmt.Errorf("check did not answer within %s: %w", c.timeout, ctx.Err())
+	}
+
+	result := Result{
+		Status:    CheckUp,
+		Required:  check.Required,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		Details:   out.details,
+	}
+	if out.err != nil {
+		result.Status = CheckDown
+		result.Error = out.err.Error()
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func up(ctx context.Context) (map[string]interface{}, error) {
+	return map[string]interface{}{"version": 3}, nil
+}
+
+func down(ctx context.Context) (map[string]interface{}, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestChecker_Run(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []Check
+		status string
+	}{
+		{
+			name:   "all up",
+			checks: []Check{{Name: "database", Required: true, Run: up}, {Name: "redis", Run: up}},
+			status: StatusReady,
+		},
+		{
+			name:   "optional down",
+			checks: []Check{{Name: "database", Required: true, Run: up}, {Name: "redis", Run: down}},
+			status: StatusDegraded,
+		},
+		{
+			name:   "required down",
+			checks: []Check{{Name: "database", Required: true, Run: down}, {Name: "redis", Run: down}},
+			status: StatusNotReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewChecker(time.Second, tt.checks...).Run(context.Background())
+
+			assert.Equal(t, tt.status, report.Status)
+			assert.Equal(t, tt.status != StatusNotReady, report.Ready())
+			require.Len(t, report.Checks, len(tt.checks))
+		})
+	}
+}
+
+func TestChecker_Run_Results(t *testing.T) {
+	report := NewChecker(time.Second,
+		Check{Name: "migrations", Required: true, Run: up},
+		Check{Name: "redis", Run: down},
+	).Run(context.Background())
+
+	migrations := report.Checks["migrations"]
+	assert.Equal(t, CheckUp, migrations.Status)
+	assert.True(t, migrations.Required)
+	assert.Equal(t, 3, migrations.Details["version"])
+	assert.Empty(t, migrations.Error)
+
+	redis := report.Checks["redis"]
+	assert.Equal(t, CheckDown, redis.Status)
+	assert.False(t, redis.Required)
+	assert.Equal(t, "connection refused", redis.Error)
+}
+
+func TestChecker_Run_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	// Ignores the context, as a stuck driver call would
+	stuck := func(ctx context.Context) (map[string]interface{}, error) {
+		<-release
+		return nil, nil
+	}
+
+	start := time.Now()
+	report := NewChecker(20*time.Millisecond, Check{Name: "database", Required: true, Run: stuck}).Run(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second, "Run should not wait for a stuck check")
+	assert.Equal(t, StatusNotReady, report.Status)
+	assert.Equal(t, CheckDown, report.Checks["database"].Status)
+	assert.Contains(t, report.Checks["database"].Error, "did not answer within 20ms")
+	assert.GreaterOrEqual(t, report.Checks["database"].LatencyMs, float64(20))
+}
@@ -0,0 +1,121 @@
+// Package metrics holds the Prometheus series of the service, served in text format by Handler on /metrics
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "item_sync"
+
+// Registry holds every series below plus the Go runtime and process collectors
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests served, by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests served, by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	SyncJobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sync_job_runs_total",
+		Help:      "Finished sync job runs, by api_source and final status.",
+	}, []string{"api_source", "status"})
+
+	SyncJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "sync_job_duration_seconds",
+		Help:      "Duration of sync job runs, by api_source and final status.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200},
+	}, []string{"api_source", "status"})
+
+	SyncJobItems = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sync_job_items_total",
+		Help:      "Items processed by sync jobs, by api_source and outcome (inserted, updated, unchanged, failed).",
+	}, []string{"api_source", "outcome"})
+
+	UpstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_requests_total",
+		Help:      "Attempts of outbound API calls, by upstream and status code (\"error\" when no response arrived).",
+	}, []string{"upstream", "status"})
+
+	UpstreamRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Latency of outbound API call attempts, by upstream, without rate limiter waits.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"upstream"})
+
+	UpstreamRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_retries_total",
+		Help:      "Retried outbound API call attempts, by upstream.",
+	}, []string{"upstream"})
+
+	BreakerTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_transitions_total",
+		Help:      "Circuit breaker state changes, by breaker and from/to state.",
+	}, []string{"breaker", "from", "to"})
+
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Item cache lookups, by operation (items, item) and result (hit, miss, error).",
+	}, []string{"operation", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		SyncJobRuns,
+		SyncJobDuration,
+		SyncJobItems,
+		UpstreamRequests,
+		UpstreamRequestDuration,
+		UpstreamRetries,
+		BreakerTransitions,
+		CacheRequests,
+	)
+}
+
+// Handler serves the registry in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records a served request, route is the route template such as /items/:id
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	HTTPRequests.WithLabelValues(method, route, code).Inc()
+	HTTPRequestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// ObserveUpstreamRequest records one attempt of an outbound call, a status of 0 means no response arrived
+func ObserveUpstreamRequest(upstream string, status int, duration time.Duration) {
+	code := "error"
+	if status > 0 {
+		code = strconv.Itoa(status)
+	}
+	UpstreamRequests.WithLabelValues(upstream, code).Inc()
+	UpstreamRequestDuration.WithLabelValues(upstream).Observe(duration.Seconds())
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	ObserveHTTPRequest(http.MethodGet, "/items/:id", http.StatusOK, 20*time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `item_sync_http_requests_total{method="GET",route="/items/:id",status="200"}`)
+	assert.Contains(t, string(body), "go_goroutines")
+}
+
+func TestObserveUpstreamRequest(t *testing.T) {
+	before := testutil.ToFloat64(UpstreamRequests.WithLabelValues("test-api", "error"))
+
+	ObserveUpstreamRequest("test-api", 0, time.Second)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(UpstreamRequests.WithLabelValues("test-api", "error")), "Calls without response should count as errors")
+}
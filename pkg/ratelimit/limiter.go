@@ -0,0 +1,255 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// resetEpochThreshold separates X-RateLimit-Reset values sent as unix timestamps from ones sent as seconds to wait
+const resetEpochThreshold = 1_000_000_000
+
+// State is a snapshot of a limiter, including the quota last reported by the upstream
+type State struct {
+	Name              string     `json:"name" example:"pokemon-api"`
+	RequestsPerMinute int        `json:"requests_per_minute" example:"100"`
+	Burst             int        `json:"burst" example:"10"`
+	AvailableTokens   float64    `json:"available_tokens" example:"7.5"`
+	BlockedUntil      *time.Time `json:"blocked_until,omitempty" description:"Set while the upstream asked us to back off"`
+	UpstreamLimit     *int       `json:"upstream_limit,omitempty" description:"Last X-RateLimit-Limit"`
+	UpstreamRemaining *int       `json:"upstream_remaining,omitempty" description:"Last X-RateLimit-Remaining"`
+	UpstreamReset     *time.Time `json:"upstream_reset,omitempty" description:"Last X-RateLimit-Reset"`
+}
+
+// Limiter is a token bucket refilled at requestsPerMinute and holding at most burst tokens. It also blocks
+// callers for as long as the upstream asks through Retry-After or an exhausted X-RateLimit-Remaining.
+type Limiter struct {
+	name              string
+	requestsPerMinute int
+	burst             int
+
+	mu           sync.Mutex
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+
+	upstreamLimit     *int
+	upstreamRemaining *int
+	upstreamReset     *time.Time
+}
+
+// NewLimiter creates a full bucket, a requestsPerMinute of zero or less only enforces upstream back-off
+func NewLimiter(name string, requestsPerMinute, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		name:              name,
+		requestsPerMinute: requestsPerMinute,
+		burst:             burst,
+		tokens:            float64(burst),
+		last:              time.Now(),
+	}
+}
+
+// Name returns the name of the upstream the limiter guards
+func (l *Limiter) Name() string {
+	return l.name
+}
+
+// Wait blocks until a token is available or the context is done
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		delay := l.reserve(time.Now())
+		l.mu.Unlock()
+
+		if delay <= 0 {
+			return ctx.Err()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token and returns zero, or returns how long to wait before trying again
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	if l.requestsPerMinute <= 0 {
+		return 0
+	}
+
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	perToken := time.Minute / time.Duration(l.requestsPerMinute)
+	return time.Duration((1 - l.tokens) * float64(perToken))
+}
+
+func (l *Limiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last)
+	l.last = now
+	if elapsed <= 0 || l.requestsPerMinute <= 0 {
+		return
+	}
+	l.tokens = min(float64(l.burst), l.tokens+elapsed.Minutes()*float64(l.requestsPerMinute))
+}
+
+// Observe records the rate limit headers of an upstream response. Retry-After on a 429 or 503 and an exhausted
+// X-RateLimit-Remaining block the limiter until the upstream is ready again.
+func (l *Limiter) Observe(statusCode int, header http.Header) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limit, ok := headerInt(header, "X-RateLimit-Limit"); ok {
+		l.upstreamLimit = &limit
+	}
+	remaining, hasRemaining := headerInt(header, "X-RateLimit-Remaining")
+	if hasRemaining {
+		l.upstreamRemaining = &remaining
+	}
+	if reset, ok := headerInt(header, "X-RateLimit-Reset"); ok {
+		resetAt := now.Add(time.Duration(reset) * time.Second)
+		if reset >= resetEpochThreshold {
+			resetAt = time.Unix(int64(reset), 0)
+		}
+		l.upstreamReset = &resetAt
+		if hasRemaining && remaining <= 0 {
+			l.blockUntil(resetAt)
+		}
+	}
+
+	if statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable {
+		if delay, ok := ParseRetryAfter(header.Get("Retry-After"), now); ok {
+			l.blockUntil(now.Add(delay))
+		}
+		// The bucket was too optimistic, start refilling from empty
+		l.refill(now)
+		l.tokens = 0
+	}
+}
+
+func (l *Limiter) blockUntil(until time.Time) {
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// State returns a snapshot of the limiter
+func (l *Limiter) State() State {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+	state := State{
+		Name:              l.name,
+		RequestsPerMinute: l.requestsPerMinute,
+		Burst:             l.burst,
+		AvailableTokens:   l.tokens,
+		UpstreamLimit:     l.upstreamLimit,
+		UpstreamRemaining: l.upstreamRemaining,
+		UpstreamReset:     l.upstreamReset,
+	}
+	if now.Before(l.blockedUntil) {
+		blockedUntil := l.blockedUntil
+		state.BlockedUntil = &blockedUntil
+	}
+	return state
+}
+
+// ParseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func headerInt(header http.Header, key string) (int, bool) {
+	value := header.Get(key)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// Manager holds one limiter per upstream, shared by every client calling it
+type Manager struct {
+	mu       sync.RWMutex
+	limiters map[string]*Limiter
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		limiters: make(map[string]*Limiter),
+	}
+}
+
+// GetLimiter returns the named limiter, creating it with the given settings on first use
+func (m *Manager) GetLimiter(name string, requestsPerMinute, burst int) *Limiter {
+	m.mu.RLock()
+	if limiter, exists := m.limiters[name]; exists {
+		m.mu.RUnlock()
+		return limiter
+	}
+	m.mu.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if limiter, exists := m.limiters[name]; exists {
+		return limiter
+	}
+
+	limiter := NewLimiter(name, requestsPerMinute, burst)
+	m.limiters[name] = limiter
+	return limiter
+}
+
+// States returns a snapshot of every limiter ordered by name
+func (m *Manager) States() []State {
+	m.mu.RLock()
+	limiters := make([]*Limiter, 0, len(m.limiters))
+	for _, limiter := range m.limiters {
+		limiters = append(limiters, limiter)
+	}
+	m.mu.RUnlock()
+
+	states := make([]State, 0, len(limiters))
+	for _, limiter := range limiters {
+		states = append(states, limiter.State())
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Wait_Burst(t *testing.T) {
+	limiter := NewLimiter("test", 600, 2) // one token every 100ms
+
+	start := time.Now()
+	for range 3 {
+		require.NoError(t, limiter.Wait(context.Background()))
+	}
+	elapsed := time.Since(start)
+
+	assert.GreaterOrEqual(t, elapsed, 80*time.Millisecond, "Third request should wait for a refill")
+	assert.Less(t, elapsed, time.Second)
+}
+
+func TestLimiter_Wait_ContextDone(t *testing.T) {
+	limiter := NewLimiter("test", 1, 1)
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestLimiter_Observe_RetryAfter(t *testing.T) {
+	limiter := NewLimiter("test", 0, 1)
+
+	header := http.Header{}
+	header.Set("Retry-After", "30")
+	limiter.Observe(http.StatusTooManyRequests, header)
+
+	state := limiter.State()
+	require.NotNil(t, state.BlockedUntil)
+	assert.WithinDuration(t, time.Now().Add(30*time.Second), *state.BlockedUntil, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded, "Requests should wait for the upstream back-off")
+}
+
+func TestLimiter_Observe_RetryAfterIgnoredOnSuccess(t *testing.T) {
+	limiter := NewLimiter("test", 0, 1)
+
+	header := http.Header{}
+	header.Set("Retry-After", "30")
+	limiter.Observe(http.StatusOK, header)
+
+	assert.Nil(t, limiter.State().BlockedUntil)
+}
+
+func TestLimiter_Observe_RateLimitHeaders(t *testing.T) {
+	limiter := NewLimiter("test", 100, 10)
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "60")
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	limiter.Observe(http.StatusOK, header)
+
+	state := limiter.State()
+	require.NotNil(t, state.UpstreamLimit)
+	require.NotNil(t, state.UpstreamRemaining)
+	require.NotNil(t, state.UpstreamReset)
+	assert.Equal(t, 60, *state.UpstreamLimit)
+	assert.Equal(t, 0, *state.UpstreamRemaining)
+	assert.True(t, reset.Equal(*state.UpstreamReset), "Epoch reset should be read as a timestamp")
+	require.NotNil(t, state.BlockedUntil, "Exhausted quota should block until the reset")
+	assert.True(t, reset.Equal(*state.BlockedUntil))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{name: "seconds", value: "120", want: 2 * time.Minute, ok: true},
+		{name: "HTTP date", value: "Mon, 15 Jan 2024 10:00:30 GMT", want: 30 * time.Second, ok: true},
+		{name: "date in the past", value: "Mon, 15 Jan 2024 09:00:00 GMT", want: 0, ok: true},
+		{name: "empty", value: "", ok: false},
+		{name: "negative", value: "-1", ok: false},
+		{name: "garbage", value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_States(t *testing.T) {
+	manager := NewManager()
+
+	pokemon := manager.GetLimiter("pokemon-api", 100, 10)
+	assert.Same(t, pokemon, manager.GetLimiter("pokemon-api", 1, 1), "Limiters should be shared by name")
+	manager.GetLimiter("openweather-api", 60, 5)
+
+	states := manager.States()
+	require.Len(t, states, 2)
+	assert.Equal(t, "openweather-api", states[0].Name)
+	assert.Equal(t, 60, states[0].RequestsPerMinute)
+	assert.Equal(t, "pokemon-api", states[1].Name)
+	assert.Equal(t, 10, states[1].Burst)
+}
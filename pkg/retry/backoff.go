@@ -8,9 +8,13 @@ import (
 
 	"github.com/zainokta/item-sync/config"
 	"github.com/zainokta/item-sync/pkg/logger"
+	"github.com/zainokta/item-sync/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Retrier struct {
+	name   string
 	config config.RetryConfig
 	logger logger.Logger
 }
@@ -22,13 +26,30 @@ func New(config config.RetryConfig, logger logger.Logger) *Retrier {
 	}
 }
 
+// Named returns a copy of the retrier that counts its retries under the given upstream name
+func (r *Retrier) Named(name string) *Retrier {
+	named := *r
+	named.name = name
+	return &named
+}
+
 func (r *Retrier) Execute(ctx context.Context, operation func() error) error {
 	var lastErr error
 	
 	for attempt := 0; attempt <= r.config.MaxRetries; attempt++ {
 		if attempt > 0 {
-			delay := r.calculateBackoff(attempt)
+			delay, ok := r.retryDelay(attempt, lastErr)
+			if !ok {
+				r.logger.Warn("Operation asked to wait longer than the maximum retry delay, giving up", "delay", delay, "max_delay", r.config.MaxDelay, "error", lastErr)
+				return lastErr
+			}
 			r.logger.Debug("Retrying operation", "attempt", attempt, "delay", delay)
+			if r.name != "" {
+				metrics.UpstreamRetries.WithLabelValues(r.name).Inc()
+			}
+			trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+				attribute.Int("attempt", attempt),
+				attribute.Int64("delay_ms", delay.Milliseconds())))
 			
 			select {
 			case <-time.After(delay):
@@ -65,7 +86,11 @@ func (r *Retrier) ExecuteWithInterface(ctx context.Context, operation func() (in
 	
 	for attempt := 0; attempt <= r.config.MaxRetries; attempt++ {
 		if attempt > 0 {
-			delay := r.calculateBackoff(attempt)
+			delay, ok := r.retryDelay(attempt, lastErr)
+			if !ok {
+				r.logger.Warn("Operation with result asked to wait longer than the maximum retry delay, giving up", "delay", delay, "max_delay", r.config.MaxDelay, "error", lastErr)
+				return result, lastErr
+			}
 			r.logger.Debug("Retrying operation with result", "attempt", attempt, "delay", delay)
 			
 			select {
@@ -105,6 +130,21 @@ func (r *Retrier) calculateBackoff(attempt int) time.Duration {
 	return delay
 }
 
+// retryDelay is the backoff for the attempt, or longer when the failed attempt asked to wait through RetryableError.After.
+// It reports false when that wait exceeds MaxDelay, the caller then gives up instead of blocking for it.
+func (r *Retrier) retryDelay(attempt int, lastErr error) (time.Duration, bool) {
+	delay := r.calculateBackoff(attempt)
+
+	var retryableErr RetryableError
+	if errors.As(lastErr, &retryableErr) && retryableErr.After > delay {
+		if retryableErr.After > r.config.MaxDelay {
+			return retryableErr.After, false
+		}
+		delay = retryableErr.After
+	}
+	return delay, true
+}
+
 func (r *Retrier) shouldRetry(err error) bool {
 	if err == nil {
 		return false
@@ -114,11 +154,14 @@ func (r *Retrier) shouldRetry(err error) bool {
 		return false
 	}
 	
-	return true
+	return IsRetryable(err)
 }
 
 type RetryableError struct {
 	Err error
+	// After is the minimum delay before the next attempt, e.g. from a Retry-After header. The retrier gives up
+	// when it exceeds MaxDelay.
+	After time.Duration
 }
 
 func (e RetryableError) Error() string {
@@ -145,6 +188,11 @@ func NewRetryableError(err error) error {
 	return RetryableError{Err: err}
 }
 
+// NewRetryableErrorAfter marks an error as retryable no sooner than after the given delay
+func NewRetryableErrorAfter(err error, after time.Duration) error {
+	return RetryableError{Err: err, After: after}
+}
+
 func NewNonRetryableError(err error) error {
 	return NonRetryableError{Err: err}
 }
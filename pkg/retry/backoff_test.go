@@ -0,0 +1,88 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"github.com/zainokta/item-sync/pkg/logger"
+)
+
+func newTestRetrier(maxRetries int) *Retrier {
+	return New(config.RetryConfig{
+		MaxRetries:    maxRetries,
+		InitialDelay:  time.Millisecond,
+		MaxDelay:      200 * time.Millisecond,
+		BackoffFactor: 2,
+	}, logger.NewLogger(logger.LevelError, "test"))
+}
+
+func TestRetrier_Execute_NonRetryable(t *testing.T) {
+	retrier := newTestRetrier(3)
+
+	attempts := 0
+	err := retrier.Execute(context.Background(), func() error {
+		attempts++
+		return NewNonRetryableError(errors.New("not found"))
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts, "Non-retryable errors should not be retried")
+}
+
+func TestRetrier_Execute_RetryAfter(t *testing.T) {
+	retrier := newTestRetrier(1)
+
+	attempts := 0
+	start := time.Now()
+	err := retrier.Execute(context.Background(), func() error {
+		attempts++
+		if attempts == 1 {
+			return NewRetryableErrorAfter(errors.New("throttled"), 100*time.Millisecond)
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "Retry should wait for the requested delay, not the shorter backoff")
+}
+
+func TestRetrier_Execute_RetryAfterTooLong(t *testing.T) {
+	retrier := newTestRetrier(3)
+
+	attempts := 0
+	start := time.Now()
+	err := retrier.Execute(context.Background(), func() error {
+		attempts++
+		return NewRetryableErrorAfter(errors.New("throttled"), time.Minute)
+	})
+
+	var retryableErr RetryableError
+	require.ErrorAs(t, err, &retryableErr)
+	assert.Equal(t, time.Minute, retryableErr.After)
+	assert.Equal(t, 1, attempts, "A Retry-After beyond the maximum delay should not be waited for")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetrier_Execute_RetryAfterCancelled(t *testing.T) {
+	retrier := New(config.RetryConfig{
+		MaxRetries:    1,
+		InitialDelay:  time.Millisecond,
+		MaxDelay:      2 * time.Minute,
+		BackoffFactor: 2,
+	}, logger.NewLogger(logger.LevelError, "test"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := retrier.Execute(ctx, func() error {
+		return NewRetryableErrorAfter(errors.New("throttled"), time.Minute)
+	})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
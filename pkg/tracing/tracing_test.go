@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zainokta/item-sync/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// resetGlobalProvider stops spans of later tests from reaching the exporter under test
+func resetGlobalProvider(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+}
+
+func TestSetup_OTLP(t *testing.T) {
+	resetGlobalProvider(t)
+
+	// Stands in for a collector, spans arrive as protobuf on /v1/traces
+	var received atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" && r.Method == http.MethodPost {
+			received.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{
+		Exporter:    ExporterOTLP,
+		Endpoint:    strings.TrimPrefix(collector.URL, "http://"),
+		Insecure:    true,
+		ServiceName: "item-sync-test",
+		SampleRatio: 1,
+	}, "test")
+	require.NoError(t, err)
+
+	_, span := Start(context.Background(), "test-span")
+	span.End()
+
+	require.NoError(t, shutdown(context.Background()))
+	assert.Equal(t, int32(1), received.Load(), "Pending spans should be flushed on shutdown")
+}
+
+func TestSetup_Stdout(t *testing.T) {
+	resetGlobalProvider(t)
+
+	var buf bytes.Buffer
+	stdout = &buf
+	t.Cleanup(func() { stdout = os.Stdout })
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: ExporterStdout, ServiceName: "item-sync-test", SampleRatio: 1}, "test")
+	require.NoError(t, err)
+
+	ctx, parent := Start(context.Background(), "parent-span")
+	_, child := Start(ctx, "child-span")
+	End(child, assert.AnError)
+	parent.End()
+
+	require.NoError(t, shutdown(context.Background()))
+	assert.Contains(t, buf.String(), `"Name":"child-span"`)
+	assert.Contains(t, buf.String(), `"Name":"parent-span"`)
+	assert.Contains(t, buf.String(), assert.AnError.Error(), "Errors passed to End should be recorded")
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	resetGlobalProvider(t)
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: ExporterDisabled}, "test")
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, span := Start(context.Background(), "test-span")
+	assert.False(t, span.IsRecording())
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), config.TracingConfig{Exporter: "jaeger"}, "test")
+	assert.ErrorContains(t, err, "unknown tracing exporter")
+}